@@ -0,0 +1,181 @@
+package h2c
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/armfazh/h2c-go-ref/curve"
+	M "github.com/armfazh/h2c-go-ref/mapping"
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Errors returned when a suite fails validation.
+var (
+	ErrSuiteRegistered      = errors.New("suite already registered")
+	ErrInvalidSecurityLevel = errors.New("invalid security level")
+	ErrInvalidL             = errors.New("invalid L")
+	ErrTooManyBytes         = errors.New("hash to field requests too many bytes")
+	ErrCurveOrder           = errors.New("curve order is not prime")
+	ErrGroupEncoding        = errors.New("maps to prime-order groups require a random oracle encoding")
+	ErrCofactorClearing     = errors.New("cofactor clearing is not defined for the curve")
+)
+
+// SuiteBuilder describes the components of a hash to curve suite. Besides the
+// well-known curves, a suite can be built on a user-supplied curve and, if the
+// mapping requires it, on a user-supplied isogeny set in Map.Iso.
+type SuiteBuilder struct {
+	E     curve.ID                   // E is the identifier of a well-known curve.
+	Curve func() (C.EllCurve, error) // Curve is a user-supplied curve, it takes precedence over E.
+	K     uint                       // K is the target security level in bits.
+	Exp   ExpanderDesc               // Exp describes the expander used by hash_to_field.
+	Map   M.MapDescriptor            // Map describes the mapping to the curve.
+	L     uint                       // L is the length in bytes per field element, if zero it is derived from K.
+	RO    bool                       // RO selects hash_to_curve, otherwise encode_to_curve is used.
+	// Clear is an optional method for clearing the cofactor, if nil points
+	// are multiplied by the cofactor of the curve.
+	Clear func() (curve.CofactorClearing, error)
+}
+
+// Get validates the components of the suite and returns a HashToPoint that
+// uses dst as domain separation tag.
+func (b SuiteBuilder) Get(dst []byte) (HashToPoint, error) {
+	e, err := b.build(dst)
+	if err != nil {
+		return nil, err
+	}
+	if b.RO {
+		return &hashToCurve{e}, nil
+	}
+	return &encodeToCurve{e}, nil
+}
+
+// Register validates the components of the suite and registers them under
+// the given SuiteID, so id.Get can find it. It returns an error if the id is
+// already registered.
+func (b SuiteBuilder) Register(id SuiteID) error {
+	if id == "" {
+		return fmt.Errorf("%w: empty identifier", ErrUnsupportedSuite)
+	}
+	if _, err := b.build([]byte(id)); err != nil {
+		return fmt.Errorf("Suite: %v: %w", id, err)
+	}
+	suitesMu.Lock()
+	defer suitesMu.Unlock()
+	if _, ok := supportedSuitesID[id]; ok {
+		return fmt.Errorf("%w: %v", ErrSuiteRegistered, id)
+	}
+	supportedSuitesID[id] = b
+	return nil
+}
+
+// GetHashToScalar returns a HashToScalar onto the integers modulo the order of
+// the group. It uses the expander of the suite with its own domain separation
+// tag dst and security level k, and L is derived from the order of the group
+// rather than from the field of the curve. For ristretto255 and decaf448, the
+// scalars are derived as in RFC 9497 instead. If k is zero, b.K is used.
+func (b SuiteBuilder) GetHashToScalar(dst []byte, k uint) (HashToScalar, error) {
+	if k == 0 {
+		k = b.K
+	}
+	if k == 0 {
+		return nil, ErrInvalidSecurityLevel
+	}
+	E, err := b.getCurve()
+	if err != nil {
+		return nil, err
+	}
+	order := E.Order()
+	if !order.ProbablyPrime(20) {
+		return nil, ErrCurveOrder
+	}
+	exp, err := b.Exp.Get(dst, k)
+	if err != nil {
+		return nil, err
+	}
+	return newScalarEncoding(order, exp, lengthL(order, k), b.Map.ID.IsGroupMap()), nil
+}
+
+func (b SuiteBuilder) getCurve() (C.EllCurve, error) {
+	if b.Curve != nil {
+		return b.Curve()
+	}
+	return b.E.Get()
+}
+
+func (b SuiteBuilder) build(dst []byte) (*encoding, error) {
+	if b.K == 0 {
+		return nil, ErrInvalidSecurityLevel
+	}
+	E, err := b.getCurve()
+	if err != nil {
+		return nil, err
+	}
+	if !E.Order().ProbablyPrime(20) {
+		return nil, ErrCurveOrder
+	}
+	m, err := b.Map.Get(E)
+	if err != nil {
+		return nil, err
+	}
+	F := E.Field()
+	L, scalarL := b.L, uint(0)
+	var decode func([]byte) GF.Elt
+	if g, ok := m.(M.GroupMap); ok {
+		if !b.RO {
+			return nil, ErrGroupEncoding
+		}
+		if L != 0 && L != g.ElementLen() {
+			return nil, fmt.Errorf("%w: got %v want %v", ErrInvalidL, L, g.ElementLen())
+		}
+		L, decode = g.ElementLen(), g.Decode
+	} else {
+		minL := lengthL(F.P(), b.K)
+		if L == 0 {
+			L = minL
+		} else if L < minL {
+			return nil, fmt.Errorf("%w: got %v want at least %v for the security level", ErrInvalidL, L, minL)
+		}
+		scalarL = L
+	}
+	count := uint(1)
+	if b.RO {
+		count = 2
+	}
+	if count*F.Ext()*L > math.MaxUint16 {
+		return nil, ErrTooManyBytes
+	}
+	var clear curve.CofactorClearing
+	if b.Clear != nil {
+		if clear, err = b.Clear(); err != nil {
+			return nil, err
+		}
+		// Curves over different fields cannot be compared.
+		if e := clear.Curve(); e.Field().Ext() != F.Ext() || e.Field().P().Cmp(F.P()) != 0 || !e.IsEqual(E) {
+			return nil, ErrCofactorClearing
+		}
+	}
+	exp, err := b.Exp.Get(dst, b.K)
+	if err != nil {
+		return nil, err
+	}
+	return &encoding{
+		E: E,
+		Field: &fieldEncoding{
+			F:      F,
+			Exp:    exp,
+			L:      L,
+			Decode: decode,
+		},
+		Mapping: m,
+		Group:   decode != nil,
+		ScalarL: scalarL,
+		Clear:   clear,
+	}, nil
+}
+
+// lengthL returns L = ceil((ceil(log2(p)) + k) / 8), the number of bytes
+// needed to obtain a field element with a bias of at most 2^-k.
+func lengthL(p *big.Int, k uint) uint { return (uint(p.BitLen()) + k + 7) / 8 }
@@ -0,0 +1,102 @@
+package h2c_test
+
+import (
+	"crypto"
+	"errors"
+	"testing"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+	"github.com/armfazh/h2c-go-ref/curve"
+	M "github.com/armfazh/h2c-go-ref/mapping"
+	"github.com/armfazh/h2c-go-ref/xof"
+	C "github.com/armfazh/tozan-ecc/curve"
+)
+
+func TestSuiteBuilder(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_")
+	msg := []byte("abc")
+	want, err := h2c.Secp256k1_XMDSHA256_SSWU_RO_.Get(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := h2c.SuiteBuilder{
+		Curve: curve.SECP256K1.Get,
+		K:     128,
+		Exp:   h2c.ExpanderDesc{Type: h2c.XMD, ID: uint(crypto.SHA256)},
+		Map:   M.MapDescriptor{ID: M.SSWU, Z: -11, Iso: curve.GetSECP256K1Isogeny},
+		RO:    true,
+	}
+	got, err := b.Get(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if P, Q := got.Hash(msg), want.Hash(msg); !P.IsEqual(Q) {
+		t.Fatalf("got:  %v\nwant: %v", P, Q)
+	}
+
+	id := h2c.SuiteID("secp256k1_XMD:SHA-256_SSWU_RO_TEST_")
+	if err := b.Register(id); err != nil {
+		t.Fatal(err)
+	}
+	defer id.Unregister()
+	if err := b.Register(id); !errors.Is(err, h2c.ErrSuiteRegistered) {
+		t.Fatalf("got:  %v\nwant: %v", err, h2c.ErrSuiteRegistered)
+	}
+	registered, err := id.Get(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if P, Q := registered.Hash(msg), want.Hash(msg); !P.IsEqual(Q) {
+		t.Fatalf("got:  %v\nwant: %v", P, Q)
+	}
+}
+
+func TestSuiteBuilderCustom(t *testing.T) {
+	for _, b := range []h2c.SuiteBuilder{
+		{E: curve.P256, K: 128, Exp: h2c.ExpanderDesc{Type: h2c.XMD, ID: uint(crypto.SHA512)}, Map: M.MapDescriptor{ID: M.SSWU, Z: -10}, RO: true},
+		{E: curve.Edwards25519, K: 128, Exp: h2c.ExpanderDesc{Type: h2c.XOF, ID: uint(xof.SHAKE128)}, Map: M.MapDescriptor{ID: M.ELL2}, RO: true},
+		{E: curve.P384, K: 192, Exp: h2c.ExpanderDesc{Type: h2c.XMD, ID: uint(crypto.SHA384)}, Map: M.MapDescriptor{ID: M.SVDW}, RO: false},
+	} {
+		hashToCurve, err := b.Get([]byte("DST"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		E := hashToCurve.GetCurve()
+		P := hashToCurve.Hash([]byte("abc"))
+		if !E.IsOnCurve(P) || !E.ScalarMult(P, E.Order()).IsIdentity() {
+			t.Fatalf("curve: %v\ngot: %v", b.E, P)
+		}
+	}
+}
+
+func TestSuiteBuilderErrors(t *testing.T) {
+	sha256 := h2c.ExpanderDesc{Type: h2c.XMD, ID: uint(crypto.SHA256)}
+	sswu := M.MapDescriptor{ID: M.SSWU, Z: -10}
+	bad := func() (C.EllCurve, error) { return nil, curve.ErrUnsupported }
+	r255 := M.MapDescriptor{ID: M.R255MAP}
+	for _, v := range []struct {
+		b   h2c.SuiteBuilder
+		err error
+	}{
+		{h2c.SuiteBuilder{E: curve.P256, K: 0, Exp: sha256, Map: sswu}, h2c.ErrInvalidSecurityLevel},
+		{h2c.SuiteBuilder{E: curve.P256, K: 128, Exp: sha256, Map: sswu, L: 32}, h2c.ErrInvalidL},
+		{h2c.SuiteBuilder{E: "P255", K: 128, Exp: sha256, Map: sswu}, curve.ErrUnsupported},
+		{h2c.SuiteBuilder{Curve: bad, K: 128, Exp: sha256, Map: sswu}, curve.ErrUnsupported},
+		{h2c.SuiteBuilder{E: curve.P256, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: 1}}, M.ErrZIsSquare},
+		{h2c.SuiteBuilder{E: curve.SECP256K1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -11}}, M.ErrAIsZero},
+		{h2c.SuiteBuilder{E: curve.Edwards25519, K: 128, Exp: sha256, Map: r255}, h2c.ErrGroupEncoding},
+		{h2c.SuiteBuilder{E: curve.Edwards25519, K: 128, Exp: sha256, Map: r255, L: 48, RO: true}, h2c.ErrInvalidL},
+		{h2c.SuiteBuilder{E: curve.Curve25519, K: 128, Exp: sha256, Map: r255, RO: true}, M.ErrCurveModel},
+		{h2c.SuiteBuilder{E: curve.Edwards448, K: 224, Exp: sha256, Map: r255, RO: true}, M.ErrUnsupported},
+		{h2c.SuiteBuilder{E: curve.Edwards25519, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.D448MAP}, RO: true}, M.ErrUnsupported},
+		{h2c.SuiteBuilder{E: curve.BN254G1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SVDW}, Clear: curve.GetBN254G2CofactorClearing}, h2c.ErrCofactorClearing},
+		{h2c.SuiteBuilder{E: curve.BN254G2, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SVDW}, Clear: curve.GetBLS12377G2CofactorClearing}, h2c.ErrCofactorClearing},
+	} {
+		if _, err := v.b.Get([]byte("DST")); !errors.Is(err, v.err) {
+			t.Fatalf("got:  %v\nwant: %v", err, v.err)
+		}
+		if err := v.b.Register("INVALID_SUITE_"); !errors.Is(err, v.err) {
+			t.Fatalf("got:  %v\nwant: %v", err, v.err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+)
+
+// expanderVectors expands each message to each of the given lengths and
+// returns the intermediate values of the expansion.
+func expanderVectors(desc h2c.ExpanderDesc, dst []byte, k uint, lengths []uint, msgs []string) (map[string]interface{}, error) {
+	exp, err := desc.Get(dst, k)
+	if err != nil {
+		return nil, err
+	}
+	tracer, ok := exp.(h2c.TraceExpander)
+	if !ok {
+		return nil, fmt.Errorf("expander %v does not expose its intermediate values", desc)
+	}
+	tests := make([]map[string]string, 0, len(lengths)*len(msgs))
+	for _, n := range lengths {
+		for _, msg := range msgs {
+			tr, err := tracer.ExpandWithTrace([]byte(msg), n)
+			if err != nil {
+				return nil, err
+			}
+			tests = append(tests, map[string]string{
+				"DST_prime":     hex.EncodeToString(tr.DSTPrime),
+				"len_in_bytes":  fmt.Sprintf("0x%x", n),
+				"msg":           msg,
+				"msg_prime":     hex.EncodeToString(tr.MsgPrime),
+				"uniform_bytes": hex.EncodeToString(tr.UniformBytes),
+			})
+		}
+	}
+	return map[string]interface{}{
+		"DST":   string(dst),
+		"hash":  hashLabel(desc),
+		"k":     k,
+		"name":  "expand_message_" + typeLabel(desc),
+		"tests": tests,
+	}, nil
+}
+
+// defaultExpanderDST returns the tag used by the expander vectors of RFC 9380.
+func defaultExpanderDST(desc h2c.ExpanderDesc, k uint) string {
+	dst := "QUUX-V01-CS02-with-expander-" + hashLabel(desc)
+	if t, _ := desc.Variant(); t == h2c.XMD {
+		dst += fmt.Sprintf("-%v", k)
+	}
+	return dst
+}
+
+// longDST extends dst to 256 bytes, so it must be hashed to get DST_prime.
+func longDST(dst string) string {
+	dst += "-long-DST-"
+	if n := h2c.MaxDSTLength + 1 - len(dst); n > 0 {
+		dst += strings.Repeat("1", n)
+	}
+	return dst
+}
+
+// expanderFileName returns the name of the file that stores vectors of an
+// expander.
+func expanderFileName(desc h2c.ExpanderDesc, dst string) string {
+	return fmt.Sprintf("expand_message_%v_%v_%v.json", typeLabel(desc), hashLabel(desc), len(dst))
+}
+
+// typeLabel and hashLabel name an expander as its Variant, so the Zcash
+// expander "XMD:BLAKE2b" is labelled as xmd and BLAKE2b.
+func typeLabel(desc h2c.ExpanderDesc) string {
+	t, _ := desc.Variant()
+	return strings.ToLower(t.String())
+}
+
+func hashLabel(desc h2c.ExpanderDesc) string {
+	_, name := desc.Variant()
+	return strings.Replace(name, "-", "", -1)
+}
@@ -0,0 +1,138 @@
+// Command h2c-vectors generates test vectors for hash to curve suites and for
+// expander functions. The output follows the schema of the files found in the
+// testdata folder of this repository.
+//
+// Usage:
+//
+//	h2c-vectors [-suite id,...] [-dst tag] [-out dir]
+//	h2c-vectors -expander XMD:SHA-256 [-k 128] [-len 0x20,0x80] [-long] [-dst tag] [-out dir]
+//
+// A suite can be any registered suite or any suite identifier whose components
+// are known (see h2c.SuiteID.Parse), e.g. P256_XMD:SHA-512_SSWU_RO_. Without
+// -out the vectors are written to the standard output.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+)
+
+// listFlag is a comma-separated list of values.
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+func (l *listFlag) Set(s string) error {
+	*l = append(*l, strings.Split(s, ",")...)
+	return nil
+}
+
+// defaultMsgs are the messages used by the test vectors of RFC 9380.
+var defaultMsgs = []string{
+	"",
+	"abc",
+	"abcdef0123456789",
+	"q128_" + strings.Repeat("q", 128),
+	"a512_" + strings.Repeat("a", 512),
+}
+
+func main() {
+	var suites, lengths listFlag
+	flag.Var(&suites, "suite", "comma-separated list of suite identifiers (default all registered suites)")
+	flag.Var(&lengths, "len", "comma-separated list of output lengths for expander vectors (default 0x20,0x80)")
+	dst := flag.String("dst", "", "domain separation tag (default QUUX-V01-CS02-with-<suite> or QUUX-V01-CS02-with-expander-<hash>)")
+	exp := flag.String("expander", "", "generate expander vectors for this HASH_ID, e.g. XMD:SHA-256 or XOF:SHAKE128")
+	k := flag.Uint("k", 128, "security level in bits for expander vectors")
+	long := flag.Bool("long", false, "extend the expander tag to 256 bytes")
+	out := flag.String("out", "", "output directory (default standard output)")
+	flag.Parse()
+
+	if err := run(suites, lengths, *dst, *exp, *k, *long, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "h2c-vectors:", err)
+		os.Exit(1)
+	}
+}
+
+func run(suites, lengths []string, dst, exp string, k uint, long bool, out string) error {
+	if exp != "" {
+		desc, err := h2c.ParseExpanderDesc(exp)
+		if err != nil {
+			return err
+		}
+		if dst == "" {
+			dst = defaultExpanderDST(desc, k)
+		}
+		if long {
+			dst = longDST(dst)
+		}
+		if len(lengths) == 0 {
+			lengths = []string{"0x20", "0x80"}
+		}
+		n := make([]uint, len(lengths))
+		for i := range lengths {
+			v, err := strconv.ParseUint(lengths[i], 0, 16)
+			if err != nil {
+				return err
+			}
+			n[i] = uint(v)
+		}
+		v, err := expanderVectors(desc, []byte(dst), k, n, defaultMsgs)
+		if err != nil {
+			return err
+		}
+		return write(out, expanderFileName(desc, dst), v)
+	}
+
+	ids := make([]h2c.SuiteID, len(suites))
+	for i := range suites {
+		ids[i] = h2c.SuiteID(suites[i])
+	}
+	if len(ids) == 0 {
+		ids = h2c.SupportedSuites()
+	}
+	for _, id := range ids {
+		tag := dst
+		if tag == "" {
+			tag = "QUUX-V01-CS02-with-" + string(id)
+		}
+		v, err := suiteVectors(id, []byte(tag), defaultMsgs)
+		if err != nil {
+			return err
+		}
+		if err := write(out, suiteFileName(id), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write stores v in the named file inside dir, or writes v to the standard
+// output if dir is empty.
+func write(dir, name string, v interface{}) error {
+	var buf bytes.Buffer
+	if err := encode(&buf, v); err != nil {
+		return err
+	}
+	if dir == "" {
+		_, err := io.Copy(os.Stdout, &buf)
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0644)
+}
+
+// encode writes v as indented JSON with sorted keys.
+func encode(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+)
+
+func TestSuiteVectors(t *testing.T) {
+	for _, id := range h2c.SupportedSuites() {
+		t.Run(string(id), func(t *testing.T) {
+			want, err := ioutil.ReadFile(filepath.Join("..", "..", "testdata", "suites", suiteFileName(id)))
+			if os.IsNotExist(err) {
+				t.Skipf("suite: %v has no vectors file", id)
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			v, err := suiteVectors(id, []byte("QUUX-V01-CS02-with-"+string(id)), defaultMsgs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got bytes.Buffer
+			if err := encode(&got, v); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Fatalf("suite: %v vectors mismatch", id)
+			}
+		})
+	}
+}
+
+func TestExpanderVectors(t *testing.T) {
+	for _, v := range []struct {
+		hashID string
+		k      uint
+		long   bool
+	}{
+		{"XMD:SHA-256", 128, false},
+		{"XMD:SHA-256", 128, true},
+		{"XMD:SHA-512", 256, false},
+		{"XMD:BLAKE2b", 128, false},
+		{"XOF:SHAKE128", 128, false},
+		{"XOF:SHAKE128", 128, true},
+		{"XOF:SHAKE256", 256, false},
+		{"XOF:TURBOSHAKE128", 128, false},
+		{"XOF:TURBOSHAKE256", 256, false},
+		{"XOF:KT128", 128, false},
+		{"XOF:KT256", 256, false},
+		{"XOF:BLAKE3", 128, false},
+		{"XOF:ASCONXOF128", 128, false},
+		{"XOF:ASCONCXOF128", 128, false},
+	} {
+		desc, err := h2c.ParseExpanderDesc(v.hashID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := defaultExpanderDST(desc, v.k)
+		if v.long {
+			dst = longDST(dst)
+		}
+		name := expanderFileName(desc, dst)
+		want, err := ioutil.ReadFile(filepath.Join("..", "..", "testdata", "expander", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		vectors, err := expanderVectors(desc, []byte(dst), v.k, []uint{0x20, 0x80}, defaultMsgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got bytes.Buffer
+		if err := encode(&got, vectors); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Bytes(), want) {
+			t.Fatalf("file: %v vectors mismatch", name)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+	"github.com/armfazh/h2c-go-ref/internal/hexfmt"
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// suiteVectors hashes each message with the suite and returns the header of
+// the suite together with the intermediate values of each hash.
+func suiteVectors(id h2c.SuiteID, dst []byte, msgs []string) (map[string]interface{}, error) {
+	d, err := id.Describe()
+	if err != nil {
+		return nil, err
+	}
+	d.DST = string(dst)
+	hashToCurve, err := id.Get(dst)
+	if err != nil {
+		return nil, err
+	}
+	header, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(header, &v); err != nil {
+		return nil, err
+	}
+
+	F := hashToCurve.GetCurve().Field()
+	vectors := make([]map[string]interface{}, len(msgs))
+	for i, msg := range msgs {
+		trace, err := hashToCurve.HashWithTrace([]byte(msg))
+		if err != nil {
+			return nil, err
+		}
+		u := make([]string, len(trace.U))
+		for j := range trace.U {
+			u[j] = hexfmt.EltToHex(F, trace.U[j])
+		}
+		vector := map[string]interface{}{
+			"msg": msg,
+			"u":   u,
+			"P":   pointToHex(F, trace.P),
+		}
+		if hashToCurve.IsRandomOracle() {
+			vector["Q0"] = pointToHex(F, trace.Q[0])
+			vector["Q1"] = pointToHex(F, trace.Q[1])
+		} else {
+			vector["Q"] = pointToHex(F, trace.Q[0])
+		}
+		vectors[i] = vector
+	}
+	v["vectors"] = vectors
+	return v, nil
+}
+
+// suiteFileName returns the name of the file that stores vectors of a suite.
+func suiteFileName(id h2c.SuiteID) string { return strings.Replace(string(id), ":", "-", -1) + ".json" }
+
+func pointToHex(F GF.Field, P C.Point) map[string]string {
+	return map[string]string{"x": hexfmt.EltToHex(F, P.X()), "y": hexfmt.EltToHex(F, P.Y())}
+}
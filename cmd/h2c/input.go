@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// readMessage returns the message given in args or stdin according to format.
+func readMessage(format string, args []string, stdin io.Reader) ([]byte, error) {
+	if format == "stdin" {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("unexpected argument %q", args[0])
+		}
+		return ioutil.ReadAll(stdin)
+	}
+	if len(args) != 1 {
+		return nil, fmt.Errorf("expected one message argument, got %v", len(args))
+	}
+	switch format {
+	case "text":
+		return []byte(args[0]), nil
+	case "hex":
+		return hex.DecodeString(strings.TrimPrefix(args[0], "0x"))
+	case "file":
+		return ioutil.ReadFile(args[0])
+	default:
+		return nil, fmt.Errorf("unknown input format %q", format)
+	}
+}
@@ -0,0 +1,88 @@
+// Command h2c hashes a message to a point, to a scalar, or to a uniform byte
+// string using the suites of this module.
+//
+// Usage:
+//
+//	h2c -suite id -dst tag [-scalar [-k level]] [-trace] [-in format] [-out format] [message]
+//	h2c -expander HASH_ID -dst tag [-k 128] [-len 32] [-in format] [-out format] [message]
+//
+// The message is read according to -in:
+//
+//	text   the message argument as is (default)
+//	hex    the message argument decoded from hexadecimal
+//	file   the contents of the file named by the message argument
+//	stdin  the standard input
+//
+// With -scalar, the message is hashed to an integer modulo the order of the
+// group using dst as its own domain separation tag.
+//
+// Points are written according to -out:
+//
+//	hex           one hexadecimal coordinate per line (default)
+//	json          an object following the schema of the test vectors
+//	compressed    0x02 or 0x03 according to sgn0(y) followed by x
+//	uncompressed  0x04 followed by x and y
+//
+// Serialized coordinates are big-endian and padded to the length of the field
+// modulus; elements of extension fields are serialized as the concatenation
+// of their coefficients. The identity point is serialized as 0x00. Elements
+// of ristretto255 and decaf448 are serialized in the compressed format with
+// the encoding of RFC 9496, and they have no uncompressed format.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	var c config
+	flag.StringVar(&c.Suite, "suite", "", "suite identifier, e.g. P256_XMD:SHA-256_SSWU_RO_")
+	flag.StringVar(&c.DST, "dst", "", "domain separation tag")
+	flag.BoolVar(&c.Scalar, "scalar", false, "hash to a scalar instead of a point")
+	flag.BoolVar(&c.Trace, "trace", false, "also print the intermediate values, e.g. msg_prime of -expander")
+	flag.StringVar(&c.Expander, "expander", "", "expand the message with this HASH_ID, e.g. XMD:SHA-256 or XOF:SHAKE128")
+	flag.UintVar(&c.K, "k", 0, "security level in bits used by -scalar and -expander (default the level of the suite, or 128)")
+	flag.UintVar(&c.Len, "len", 32, "length in bytes of the output of -expander")
+	flag.StringVar(&c.In, "in", "text", "input format: text, hex, file, or stdin")
+	flag.StringVar(&c.Out, "out", "hex", "output format: hex, json, compressed, or uncompressed")
+	flag.Parse()
+
+	if err := run(c, flag.Args(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "h2c:", err)
+		os.Exit(1)
+	}
+}
+
+// config holds the options of the command.
+type config struct {
+	Suite    string
+	DST      string
+	Scalar   bool
+	Trace    bool
+	Expander string
+	K        uint
+	Len      uint
+	In       string
+	Out      string
+}
+
+func run(c config, args []string, stdin io.Reader, stdout io.Writer) error {
+	if c.DST == "" {
+		return fmt.Errorf("missing -dst")
+	}
+	msg, err := readMessage(c.In, args, stdin)
+	if err != nil {
+		return err
+	}
+	switch {
+	case c.Expander != "":
+		return expand(c, msg, stdout)
+	case c.Suite != "":
+		return hash(c, msg, stdout)
+	default:
+		return fmt.Errorf("missing -suite or -expander")
+	}
+}
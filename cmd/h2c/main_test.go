@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+	"github.com/armfazh/h2c-go-ref/decaf448"
+	"github.com/armfazh/h2c-go-ref/ristretto255"
+)
+
+func TestRun(t *testing.T) {
+	const suite = "P256_XMD:SHA-256_SSWU_RO_"
+	const dst = "QUUX-V01-CS02-with-" + suite
+	const x = "0bb8b87485551aa43ed54f009230450b492fead5f1cc91658775dac4a3388a0f"
+	const y = "5c41b3d0731a27a7b14bc0bf0ccded2d8751f83493404c84a88e71ffd424212e"
+	for _, v := range []struct {
+		c     config
+		args  []string
+		stdin string
+		want  string
+	}{
+		{config{Suite: suite, DST: dst, In: "text", Out: "hex"}, []string{"abc"}, "", "P.x: 0x" + x + "\nP.y: 0x" + y + "\n"},
+		{config{Suite: suite, DST: dst, In: "hex", Out: "uncompressed"}, []string{"616263"}, "", "04" + x + y + "\n"},
+		{config{Suite: suite, DST: dst, In: "stdin", Out: "compressed"}, nil, "abc", "02" + x + "\n"},
+		{config{Suite: suite, DST: dst, In: "text", Out: "json"}, []string{"abc"}, "", "{\n  \"P\": {\n    \"x\": \"0x" + x + "\",\n    \"y\": \"0x" + y + "\"\n  }\n}\n"},
+		{
+			config{Expander: "XMD:SHA-256", DST: "QUUX-V01-CS02-with-expander-SHA256-128", K: 128, Len: 0x20, In: "text", Out: "hex"},
+			[]string{"abc"}, "", "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615\n",
+		},
+	} {
+		var out bytes.Buffer
+		if err := run(v.c, v.args, strings.NewReader(v.stdin), &out); err != nil {
+			t.Fatal(err)
+		}
+		if got := out.String(); got != v.want {
+			t.Fatalf("config: %+v\ngot:  %q\nwant: %q", v.c, got, v.want)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, v := range []struct {
+		c    config
+		args []string
+	}{
+		{config{Suite: "P256_XMD:SHA-256_SSWU_RO_", In: "text", Out: "hex"}, []string{"abc"}},
+		{config{DST: "DST", In: "text", Out: "hex"}, []string{"abc"}},
+		{config{Suite: "P256_XMD:SHA-256_SSWU_RO_", DST: "DST", In: "hex", Out: "hex"}, []string{"xyz"}},
+		{config{Suite: "P256_XMD:SHA-256_SSWU_RO_", DST: "DST", In: "text", Out: "pem"}, []string{"abc"}},
+		{config{Suite: "P255_XMD:SHA-256_SSWU_RO_", DST: "DST", In: "text", Out: "hex"}, []string{"abc"}},
+		{config{Expander: "XMD:SHA-999", DST: "DST", In: "text", Out: "hex"}, []string{"abc"}},
+		{config{Suite: "ristretto255_XMD:SHA-512_R255MAP_RO_", DST: "DST", In: "text", Out: "uncompressed"}, []string{"abc"}},
+	} {
+		if err := run(v.c, v.args, strings.NewReader(""), &bytes.Buffer{}); err == nil {
+			t.Fatalf("config: %+v expected error", v.c)
+		}
+	}
+}
+
+func TestRunGroup(t *testing.T) {
+	for _, id := range []h2c.SuiteID{h2c.Ristretto255_XMDSHA512_R255MAP_RO_, h2c.Decaf448_XOFSHAKE256_D448MAP_RO_} {
+		dst := "QUUX-V01-CS02-with-" + string(id)
+		var out bytes.Buffer
+		if err := run(config{Suite: string(id), DST: dst, In: "text", Out: "compressed"}, []string{"abc"}, strings.NewReader(""), &out); err != nil {
+			t.Fatal(err)
+		}
+		hashToGroup, err := id.Get([]byte(dst))
+		if err != nil {
+			t.Fatal(err)
+		}
+		P := hashToGroup.Hash([]byte("abc"))
+		want := ristretto255.NewElement(P).Encode
+		if id == h2c.Decaf448_XOFSHAKE256_D448MAP_RO_ {
+			want = decaf448.NewElement(P).Encode
+		}
+		if got, want := out.String(), hex.EncodeToString(want())+"\n"; got != want {
+			t.Fatalf("suite: %v\ngot:  %q\nwant: %q", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+	"github.com/armfazh/h2c-go-ref/decaf448"
+	"github.com/armfazh/h2c-go-ref/internal/hexfmt"
+	M "github.com/armfazh/h2c-go-ref/mapping"
+	"github.com/armfazh/h2c-go-ref/ristretto255"
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// hash hashes msg to a point, or to a scalar, and writes the result.
+func hash(c config, msg []byte, w io.Writer) error {
+	if c.Scalar {
+		s, err := h2c.SuiteID(c.Suite).GetHashToScalar([]byte(c.DST), c.K)
+		if err != nil {
+			return err
+		}
+		return writeScalar(c.Out, w, s.GetScalarField(), s.Hash(msg))
+	}
+	hashToCurve, err := h2c.SuiteID(c.Suite).Get([]byte(c.DST))
+	if err != nil {
+		return err
+	}
+
+	F := hashToCurve.GetCurve().Field()
+	trace, err := hashToCurve.HashWithTrace(msg)
+	if err != nil {
+		return err
+	}
+	var names []string
+	var points []C.Point
+	if c.Trace {
+		if hashToCurve.IsRandomOracle() {
+			names = append(names, "Q0", "Q1")
+		} else {
+			names = append(names, "Q")
+		}
+		points = append(points, trace.Q...)
+	}
+	names = append(names, "P")
+	points = append(points, trace.P)
+
+	var u []GF.Elt
+	if c.Trace {
+		u = trace.U
+	}
+	switch c.Out {
+	case "json":
+		v := make(map[string]interface{})
+		if c.Trace {
+			s := make([]string, len(u))
+			for i := range u {
+				s[i] = hexfmt.EltToHex(F, u[i])
+			}
+			v["u"] = s
+		}
+		for i := range points {
+			v[names[i]] = pointToJSON(F, points[i])
+		}
+		return writeJSON(w, v)
+	case "hex":
+		for i := range u {
+			fmt.Fprintf(w, "u[%v]: %v\n", i, hexfmt.EltToHex(F, u[i]))
+		}
+		for i := range points {
+			if points[i].IsIdentity() && points[i].X() == nil {
+				fmt.Fprintf(w, "%v: identity\n", names[i])
+				continue
+			}
+			fmt.Fprintf(w, "%v.x: %v\n", names[i], hexfmt.EltToHex(F, points[i].X()))
+			fmt.Fprintf(w, "%v.y: %v\n", names[i], hexfmt.EltToHex(F, points[i].Y()))
+		}
+		return nil
+	case "compressed", "uncompressed":
+		encode := func(P C.Point) []byte { return pointToBytes(F, P, c.Out == "compressed") }
+		if id, err := h2c.SuiteID(c.Suite).Parse(); err == nil {
+			if enc, ok := groupEncoders[id.Map]; ok {
+				if c.Out == "uncompressed" {
+					return fmt.Errorf("elements of %v have no uncompressed format", id.Curve)
+				}
+				encode = enc
+			}
+		}
+		for i := range u {
+			fmt.Fprintf(w, "u[%v]: %x\n", i, eltToBytes(F, u[i]))
+		}
+		for i := range points {
+			b := encode(points[i])
+			if c.Trace {
+				fmt.Fprintf(w, "%v: %x\n", names[i], b)
+			} else {
+				fmt.Fprintf(w, "%x\n", b)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q", c.Out)
+	}
+}
+
+// expand writes the output of expand_message applied on msg.
+func expand(c config, msg []byte, w io.Writer) error {
+	desc, err := h2c.ParseExpanderDesc(c.Expander)
+	if err != nil {
+		return err
+	}
+	k := c.K
+	if k == 0 {
+		k = 128
+	}
+	exp, err := desc.Get([]byte(c.DST), k)
+	if err != nil {
+		return err
+	}
+	tracer, ok := exp.(h2c.TraceExpander)
+	if !c.Trace || !ok {
+		pseudo, err := exp.ExpandMessage(msg, c.Len)
+		if err != nil {
+			return err
+		}
+		return writeValues(c.Out, w, [][2]string{{"uniform_bytes", hex.EncodeToString(pseudo)}})
+	}
+	tr, err := tracer.ExpandWithTrace(msg, c.Len)
+	if err != nil {
+		return err
+	}
+	values := [][2]string{
+		{"DST_prime", hex.EncodeToString(tr.DSTPrime)},
+		{"msg_prime", hex.EncodeToString(tr.MsgPrime)},
+	}
+	for i := range tr.B {
+		values = append(values, [2]string{fmt.Sprintf("b_%v", i), hex.EncodeToString(tr.B[i])})
+	}
+	values = append(values, [2]string{"uniform_bytes", hex.EncodeToString(tr.UniformBytes)})
+	return writeValues(c.Out, w, values)
+}
+
+// writeValues writes named byte strings; in text formats a single value is
+// written without its name.
+func writeValues(format string, w io.Writer, values [][2]string) error {
+	switch format {
+	case "json":
+		v := make(map[string]string)
+		for _, kv := range values {
+			v[kv[0]] = kv[1]
+		}
+		return writeJSON(w, v)
+	case "hex", "compressed", "uncompressed":
+		for _, kv := range values {
+			var err error
+			if len(values) == 1 {
+				_, err = fmt.Fprintln(w, kv[1])
+			} else {
+				_, err = fmt.Fprintf(w, "%v: %v\n", kv[0], kv[1])
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+func writeScalar(format string, w io.Writer, F GF.Field, s GF.Elt) error {
+	switch format {
+	case "json":
+		return writeJSON(w, map[string]string{"scalar": hexfmt.EltToHex(F, s)})
+	case "hex":
+		_, err := fmt.Fprintln(w, hexfmt.EltToHex(F, s))
+		return err
+	case "compressed", "uncompressed":
+		_, err := fmt.Fprintf(w, "%x\n", eltToBytes(F, s))
+		return err
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func byteLen(F GF.Field) int { return (F.P().BitLen() + 7) / 8 }
+
+// eltToBytes concatenates the big-endian encoding of each coefficient of a
+// field element.
+func eltToBytes(F GF.Field, e GF.Elt) []byte {
+	n := byteLen(F)
+	var out []byte
+	for _, c := range e.Polynomial() {
+		out = append(out, pad(c, n)...)
+	}
+	return out
+}
+
+func pad(c *big.Int, n int) []byte {
+	b := c.Bytes()
+	return append(make([]byte, n-len(b)), b...)
+}
+
+func pointToJSON(F GF.Field, P C.Point) interface{} {
+	if P.IsIdentity() && P.X() == nil {
+		return nil
+	}
+	return map[string]string{"x": hexfmt.EltToHex(F, P.X()), "y": hexfmt.EltToHex(F, P.Y())}
+}
+
+// groupEncoders serialize the elements of the prime-order groups of RFC 9496,
+// which are represented by any point of a coset, with their canonical
+// encoding.
+var groupEncoders = map[string]func(C.Point) []byte{
+	M.R255MAP.String(): func(P C.Point) []byte { return ristretto255.NewElement(P).Encode() },
+	M.D448MAP.String(): func(P C.Point) []byte { return decaf448.NewElement(P).Encode() },
+}
+
+// pointToBytes serializes a point following the SEC1 format.
+func pointToBytes(F GF.Field, P C.Point, compress bool) []byte {
+	if P.IsIdentity() && P.X() == nil {
+		return []byte{0x00}
+	}
+	x := eltToBytes(F, P.X())
+	if compress {
+		return append([]byte{byte(0x02 | F.Sgn0(P.Y()))}, x...)
+	}
+	return append(append([]byte{0x04}, x...), eltToBytes(F, P.Y())...)
+}
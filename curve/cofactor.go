@@ -0,0 +1,140 @@
+package curve
+
+import (
+	"fmt"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// CofactorClearing is a method for clearing the cofactor of a curve that is
+// faster than multiplying by the cofactor. It maps points of the curve to
+// points of the subgroup of prime order.
+type CofactorClearing interface {
+	Curve() C.EllCurve
+	Clear(C.Point) C.Point
+}
+
+// twistPsi is the untwist-Frobenius-twist endomorphism of a sextic twist
+// y^2=x^3+B over Fp2, that is, psi(x,y) = (conj(x)*cx, conj(y)*cy) with
+// cx = xi^((p-1)/3) and cy = xi^((p-1)/2) for the non-residue xi of the twist.
+type twistPsi struct {
+	E      C.EllCurve
+	cx, cy GF.Elt
+}
+
+func newTwistPsi(E C.EllCurve, xi GF.Elt) twistPsi {
+	F := E.Field()
+	pMinus1 := new(big.Int).Sub(F.P(), big.NewInt(1))
+	return twistPsi{
+		E:  E,
+		cx: F.Exp(xi, new(big.Int).Div(pMinus1, big.NewInt(3))),
+		cy: F.Exp(xi, new(big.Int).Rsh(pMinus1, 1)),
+	}
+}
+
+func (c twistPsi) psi(P C.Point) C.Point {
+	if P.IsIdentity() {
+		return P
+	}
+	F := c.E.Field()
+	return c.E.NewPoint(F.Mul(conj(F, P.X()), c.cx), F.Mul(conj(F, P.Y()), c.cy))
+}
+
+type bn254G2Clearing struct {
+	twistPsi
+	x *big.Int // x is the parameter of the BN254 curve.
+}
+
+// GetBN254G2CofactorClearing returns the cofactor clearing of BN254G2 by
+// Fuentes-Castañeda, Knapp and Rodríguez-Henríquez, which computes
+// [x]P + psi([3x]P) + psi^2([x]P) + psi^3(P) using the untwist-Frobenius-twist
+// endomorphism psi.
+func GetBN254G2CofactorClearing() (CofactorClearing, error) {
+	E, err := BN254G2.Get()
+	if err != nil {
+		return nil, err
+	}
+	xi := E.Field().Elt([]interface{}{9, 1}) // xi = 9+u, the non-residue of the twist.
+	return bn254G2Clearing{
+		twistPsi: newTwistPsi(E, xi),
+		x:        str2bigInt("4965661367192848881"),
+	}, nil
+}
+
+func (c bn254G2Clearing) String() string    { return fmt.Sprintf("Cofactor clearing of %v", c.E) }
+func (c bn254G2Clearing) Curve() C.EllCurve { return c.E }
+
+func (c bn254G2Clearing) Clear(P C.Point) C.Point {
+	E := c.E
+	xP := E.ScalarMult(P, c.x)           // [x]P
+	t0 := c.psi(E.Add(E.Double(xP), xP)) // psi([3x]P)
+	t1 := c.psi(c.psi(xP))               // psi^2([x]P)
+	t2 := c.psi(c.psi(c.psi(P)))         // psi^3(P)
+	return E.Add(E.Add(xP, t0), E.Add(t1, t2))
+}
+
+// bls12377X is the parameter x of the BLS12-377 curve.
+const bls12377X = "0x8508c00000000001"
+
+type bls12377G1Clearing struct {
+	E C.EllCurve
+	x *big.Int
+}
+
+// GetBLS12377G1CofactorClearing returns the cofactor clearing of BLS12377G1
+// given in Section 5 of eprint 2019/403, which multiplies points by 1-x.
+func GetBLS12377G1CofactorClearing() (CofactorClearing, error) {
+	E, err := BLS12377G1.Get()
+	if err != nil {
+		return nil, err
+	}
+	return bls12377G1Clearing{E: E, x: str2bigInt(bls12377X)}, nil
+}
+
+func (c bls12377G1Clearing) String() string    { return fmt.Sprintf("Cofactor clearing of %v", c.E) }
+func (c bls12377G1Clearing) Curve() C.EllCurve { return c.E }
+func (c bls12377G1Clearing) Clear(P C.Point) C.Point {
+	return c.E.Add(P, c.E.Neg(c.E.ScalarMult(P, c.x))) // P-[x]P
+}
+
+type bls12377G2Clearing struct {
+	twistPsi
+	x *big.Int
+}
+
+// GetBLS12377G2CofactorClearing returns the cofactor clearing of BLS12377G2
+// by Budroni and Pintore, which computes
+// [x^2-x-1]P + psi([x-1]P) + psi^2([2]P), the same method used for
+// BLS12-381 in Appendix G.3 of RFC 9380.
+func GetBLS12377G2CofactorClearing() (CofactorClearing, error) {
+	E, err := BLS12377G2.Get()
+	if err != nil {
+		return nil, err
+	}
+	xi := E.Field().Elt([]interface{}{0, 1}) // xi = u, the non-residue of the twist.
+	return bls12377G2Clearing{
+		twistPsi: newTwistPsi(E, xi),
+		x:        str2bigInt(bls12377X),
+	}, nil
+}
+
+func (c bls12377G2Clearing) String() string    { return fmt.Sprintf("Cofactor clearing of %v", c.E) }
+func (c bls12377G2Clearing) Curve() C.EllCurve { return c.E }
+
+func (c bls12377G2Clearing) Clear(P C.Point) C.Point {
+	E := c.E
+	xP := E.ScalarMult(P, c.x)                         // [x]P
+	xMinus1P := E.Add(xP, E.Neg(P))                    // [x-1]P
+	t0 := E.Add(E.ScalarMult(xMinus1P, c.x), E.Neg(P)) // [x^2-x-1]P
+	t1 := c.psi(xMinus1P)                              // psi([x-1]P)
+	t2 := c.psi(c.psi(E.Double(P)))                    // psi^2([2]P)
+	return E.Add(E.Add(t0, t1), t2)
+}
+
+// conj returns the conjugate a-bu of a+bu in a quadratic extension.
+func conj(F GF.Field, e GF.Elt) GF.Elt {
+	v := e.Polynomial()
+	return F.Elt([]interface{}{v[0], new(big.Int).Neg(v[1])})
+}
@@ -1,14 +1,20 @@
 package curve
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
 	GF "github.com/armfazh/h2c-go-ref/field"
 	C "github.com/armfazh/tozan-ecc/curve"
 )
 
+// ID is an identifier of a well-known elliptic curve.
 type ID string
 
+// ErrUnsupported is returned when a curve identifier is not known.
+var ErrUnsupported = errors.New("curve: not supported")
+
 const (
 	P256             ID = "P256"
 	P384             ID = "P384"
@@ -23,104 +29,200 @@ const (
 	BLS12381G1_11ISO ID = "BLS12381G1_11ISO"
 	BLS12381G2       ID = "BLS12381G2"
 	BLS12381G2_3ISO  ID = "BLS12381G2_3ISO"
+	BN254G1          ID = "BN254G1"
+	BN254G2          ID = "BN254G2"
+	BLS12377G1       ID = "BLS12377G1"
+	BLS12377G1_2ISO  ID = "BLS12377G1_2ISO"
+	BLS12377G2       ID = "BLS12377G2"
+	Pallas           ID = "Pallas"
+	Pallas_3ISO      ID = "Pallas_3ISO"
+	Vesta            ID = "Vesta"
+	Vesta_3ISO       ID = "Vesta_3ISO"
 )
 
-// Get returns a specific instance of an elliptic curve.
-func (id ID) Get() C.EllCurve {
+// Get returns a specific instance of an elliptic curve, otherwise returns an
+// error if the identifier is not supported.
+func (id ID) Get() (C.EllCurve, error) {
+	f, err := id.field().Get()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, id)
+	}
 	switch id {
 	case P256:
-		f := GF.P256.Get()
 		return C.Weierstrass.New(string(id), f,
 			f.Elt("-3"),
 			f.Elt("0x5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b"),
 			str2bigInt("0xffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551"),
-			big.NewInt(1))
+			big.NewInt(1)), nil
 	case P384:
-		f := GF.P384.Get()
 		return C.Weierstrass.New(string(id), f,
 			f.Elt("-3"),
 			f.Elt("0xb3312fa7e23ee7e4988e056be3f82d19181d9c6efe8141120314088f5013875ac656398d8a2ed19d2a85c8edd3ec2aef"),
 			str2bigInt("0xffffffffffffffffffffffffffffffffffffffffffffffffc7634d81f4372ddf581a0db248b0a77aecec196accc52973"),
-			big.NewInt(1))
+			big.NewInt(1)), nil
 	case P521:
-		f := GF.P521.Get()
 		return C.Weierstrass.New(string(id), f,
 			f.Elt("-3"),
 			f.Elt("0x051953eb9618e1c9a1f929a21a0b68540eea2da725b99b315f3b8b489918ef109e156193951ec7e937b1652c0bd3bb1bf073573df883d2c34f1ef451fd46b503f00"),
 			str2bigInt("0x1fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffa51868783bf2f966b7fcc0148f709a5d03bb5c9b8899c47aebb6fb71e91386409"),
-			big.NewInt(1))
+			big.NewInt(1)), nil
 	case SECP256K1:
-		f := GF.P256K1.Get()
 		return C.Weierstrass.New(string(id), f,
 			f.Zero(),
 			f.Elt("7"),
 			str2bigInt("0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
-			big.NewInt(1))
+			big.NewInt(1)), nil
 	case SECP256K1_3ISO:
-		f := GF.P256K1.Get()
 		return C.Weierstrass.New(string(id), f,
 			f.Elt("0x3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533"),
 			f.Elt("1771"),
 			str2bigInt("0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
-			big.NewInt(1))
+			big.NewInt(1)), nil
 	case Curve25519:
-		f := GF.P25519.Get()
 		return C.Montgomery.New(string(id), f,
 			f.Elt("486662"),
 			f.One(),
 			str2bigInt("0x1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed"),
-			big.NewInt(8))
+			big.NewInt(8)), nil
 	case Edwards25519:
-		f := GF.P25519.Get()
 		return C.TwistedEdwards.New(string(id), f,
 			f.Elt("-1"),
 			f.Elt("0x52036cee2b6ffe738cc740797779e89800700a4d4141d8ab75eb4dca135978a3"),
 			str2bigInt("0x1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed"),
-			big.NewInt(8))
+			big.NewInt(8)), nil
 	case Curve448:
-		f := GF.P448.Get()
 		return C.Montgomery.New(string(id), f,
 			f.Elt("156326"),
 			f.One(),
 			str2bigInt("0x3fffffffffffffffffffffffffffffffffffffffffffffffffffffff7cca23e9c44edb49aed63690216cc2728dc58f552378c292ab5844f3"),
-			big.NewInt(4))
+			big.NewInt(4)), nil
 	case Edwards448:
-		f := GF.P448.Get()
 		return C.TwistedEdwards.New(string(id), f,
 			f.One(),
 			f.Elt("-39081"),
 			str2bigInt("0x3fffffffffffffffffffffffffffffffffffffffffffffffffffffff7cca23e9c44edb49aed63690216cc2728dc58f552378c292ab5844f3"),
-			big.NewInt(4))
+			big.NewInt(4)), nil
 	case BLS12381G1:
-		f := GF.BLS12381G1.Get()
 		return C.Weierstrass.New(string(id), f,
 			f.Zero(),
 			f.Elt(4),
 			str2bigInt("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"),
-			str2bigInt("0xd201000000010001"))
+			str2bigInt("0xd201000000010001")), nil
 	case BLS12381G1_11ISO:
-		f := GF.BLS12381G1.Get()
 		return C.Weierstrass.New(string(id), f,
 			f.Elt("0x144698a3b8e9433d693a02c96d4982b0ea985383ee66a8d8e8981aefd881ac98936f8da0e0f97f5cf428082d584c1d"),
 			f.Elt("0x12e2908d11688030018b12e8753eee3b2016c1f0f24f4070a0b9c14fcef35ef55a23215a316ceaa5d1cc48e98e172be0"),
 			str2bigInt("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"),
-			str2bigInt("0xd201000000010001"))
+			str2bigInt("0xd201000000010001")), nil
 	case BLS12381G2:
-		f := GF.BLS12381G2.Get()
 		return C.Weierstrass.New(string(id), f,
 			f.Zero(),
 			f.Elt([]interface{}{4, 4}),
 			str2bigInt("0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab"),
-			str2bigInt("0xbc69f08f2ee75b3584c6a0ea91b352888e2a8e9145ad7689986ff031508ffe1329c2f178731db956d82bf015d1212b02ec0ec69d7477c1ae954cbc06689f6a359894c0adebbf6b4e8020005aaa95551"))
+			str2bigInt("0xbc69f08f2ee75b3584c6a0ea91b352888e2a8e9145ad7689986ff031508ffe1329c2f178731db956d82bf015d1212b02ec0ec69d7477c1ae954cbc06689f6a359894c0adebbf6b4e8020005aaa95551")), nil
 	case BLS12381G2_3ISO:
-		f := GF.BLS12381G2.Get()
 		return C.Weierstrass.New(string(id), f,
 			f.Elt([]interface{}{0, 240}),
 			f.Elt([]interface{}{1012, 1012}),
 			str2bigInt("0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab"),
-			str2bigInt("0xbc69f08f2ee75b3584c6a0ea91b352888e2a8e9145ad7689986ff031508ffe1329c2f178731db956d82bf015d1212b02ec0ec69d7477c1ae954cbc06689f6a359894c0adebbf6b4e8020005aaa95551"))
+			str2bigInt("0xbc69f08f2ee75b3584c6a0ea91b352888e2a8e9145ad7689986ff031508ffe1329c2f178731db956d82bf015d1212b02ec0ec69d7477c1ae954cbc06689f6a359894c0adebbf6b4e8020005aaa95551")), nil
+	case BN254G1:
+		return C.Weierstrass.New(string(id), f,
+			f.Zero(),
+			f.Elt(3),
+			str2bigInt("21888242871839275222246405745257275088548364400416034343698204186575808495617"),
+			big.NewInt(1)), nil
+	case BN254G2:
+		return C.Weierstrass.New(string(id), f,
+			f.Zero(),
+			f.Elt([]interface{}{ // B = 3/(9+u)
+				"19485874751759354771024239261021720505790618469301721065564631296452457478373",
+				"266929791119991161246907387137283842545076965332900288569378510910307636690",
+			}),
+			str2bigInt("21888242871839275222246405745257275088548364400416034343698204186575808495617"),
+			str2bigInt("21888242871839275222246405745257275088844257914179612981679871602714643921549")), nil
+	case BLS12377G1:
+		return C.Weierstrass.New(string(id), f,
+			f.Zero(),
+			f.One(),
+			str2bigInt("0x12ab655e9a2ca55660b44d1e5c37b00159aa76fed00000010a11800000000001"),
+			str2bigInt("0x170b5d44300000000000000000000000")), nil
+	case BLS12377G1_2ISO:
+		return C.Weierstrass.New(string(id), f,
+			f.Elt("0x1ae3a4617c510ea34b3c4687866d1616212919cefb9b37e860f40fde03873fc0a0bf847bffffff8b9857ffffffffff2"),
+			f.Elt(22),
+			str2bigInt("0x12ab655e9a2ca55660b44d1e5c37b00159aa76fed00000010a11800000000001"),
+			str2bigInt("0x170b5d44300000000000000000000000")), nil
+	case BLS12377G2:
+		return C.Weierstrass.New(string(id), f,
+			f.Zero(),
+			f.Elt([]interface{}{0, // B = 1/u
+				"155198655607781456406391640216936120121836107652948796323930557600032281009004493664981332883744016074664192874906",
+			}),
+			str2bigInt("0x12ab655e9a2ca55660b44d1e5c37b00159aa76fed00000010a11800000000001"),
+			str2bigInt("7923214915284317143930293550643874566881017850177945424769256759165301436616933228209277966774092486467289478618404761412630691835764674559376407658497")), nil
+	case Pallas:
+		return C.Weierstrass.New(string(id), f,
+			f.Zero(),
+			f.Elt(5),
+			str2bigInt("0x40000000000000000000000000000000224698fc0994a8dd8c46eb2100000001"),
+			big.NewInt(1)), nil
+	case Pallas_3ISO:
+		return C.Weierstrass.New(string(id), f,
+			f.Elt("0x18354a2eb0ea8c9c49be2d7258370742b74134581a27a59f92bb4b0b657a014b"),
+			f.Elt(1265),
+			str2bigInt("0x40000000000000000000000000000000224698fc0994a8dd8c46eb2100000001"),
+			big.NewInt(1)), nil
+	case Vesta:
+		return C.Weierstrass.New(string(id), f,
+			f.Zero(),
+			f.Elt(5),
+			str2bigInt("0x40000000000000000000000000000000224698fc094cf91b992d30ed00000001"),
+			big.NewInt(1)), nil
+	case Vesta_3ISO:
+		return C.Weierstrass.New(string(id), f,
+			f.Elt("0x267f9b2ee592271a81639c4d96f787739673928c7d01b212c515ad7242eaa6b1"),
+			f.Elt(1265),
+			str2bigInt("0x40000000000000000000000000000000224698fc094cf91b992d30ed00000001"),
+			big.NewInt(1)), nil
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, id)
+	}
+}
+
+// field returns the identifier of the field over which the curve is defined.
+func (id ID) field() GF.ID {
+	switch id {
+	case P256:
+		return GF.P256
+	case P384:
+		return GF.P384
+	case P521:
+		return GF.P521
+	case SECP256K1, SECP256K1_3ISO:
+		return GF.P256K1
+	case Curve25519, Edwards25519:
+		return GF.P25519
+	case Curve448, Edwards448:
+		return GF.P448
+	case BLS12381G1, BLS12381G1_11ISO:
+		return GF.BLS12381G1
+	case BLS12381G2, BLS12381G2_3ISO:
+		return GF.BLS12381G2
+	case BN254G1:
+		return GF.BN254G1
+	case BN254G2:
+		return GF.BN254G2
+	case BLS12377G1, BLS12377G1_2ISO:
+		return GF.BLS12377G1
+	case BLS12377G2:
+		return GF.BLS12377G2
+	case Pallas, Pallas_3ISO:
+		return GF.Pallas
+	case Vesta, Vesta_3ISO:
+		return GF.Vesta
 	default:
-		panic("curve not supported")
+		return ""
 	}
 }
 
@@ -7,6 +7,17 @@ import (
 	GF "github.com/armfazh/tozan-ecc/field"
 )
 
+// getPair returns the domain and codomain curves of a map between curves.
+func getPair(dom, cod ID) (e0, e1 C.EllCurve, err error) {
+	if e0, err = dom.Get(); err != nil {
+		return nil, nil, err
+	}
+	if e1, err = cod.Get(); err != nil {
+		return nil, nil, err
+	}
+	return e0, e1, nil
+}
+
 type te2mt25519 struct {
 	E0       C.EllCurve // Twisted Edwards
 	E1       C.EllCurve // Montgomery
@@ -14,15 +25,17 @@ type te2mt25519 struct {
 }
 
 // FromTe2Mt25519 returns the birational map between Edwards25519 and Curve25519 curves.
-func FromTe2Mt25519() C.RationalMap {
-	e0 := Edwards25519.Get()
-	e1 := Curve25519.Get()
+func FromTe2Mt25519() (C.RationalMap, error) {
+	e0, e1, err := getPair(Edwards25519, Curve25519)
+	if err != nil {
+		return nil, err
+	}
 	F := e0.Field()
 	return te2mt25519{
 		E0:       e0,
 		E1:       e1,
 		invSqrtD: F.Elt("6853475219497561581579357271197624642482790079785650197046958215289687604742"),
-	}
+	}, nil
 }
 func (m te2mt25519) String() string       { return fmt.Sprintf("Rational Map from %v to\n%v", m.E0, m.E1) }
 func (m te2mt25519) Domain() C.EllCurve   { return m.E0 }
@@ -67,7 +80,13 @@ type te2mt4iso448 struct {
 }
 
 // FromTe2Mt4ISO448 returns the four-degree isogeny between Edwards448 and Curve448 curves.
-func FromTe2Mt4ISO448() C.RationalMap       { return te2mt4iso448{Edwards448.Get(), Curve448.Get()} }
+func FromTe2Mt4ISO448() (C.RationalMap, error) {
+	e0, e1, err := getPair(Edwards448, Curve448)
+	if err != nil {
+		return nil, err
+	}
+	return te2mt4iso448{e0, e1}, nil
+}
 func (m te2mt4iso448) String() string       { return fmt.Sprintf("4-Isogeny from %v to\n%v", m.E0, m.E1) }
 func (m te2mt4iso448) Domain() C.EllCurve   { return m.E0 }
 func (m te2mt4iso448) Codomain() C.EllCurve { return m.E1 }
@@ -129,9 +148,11 @@ type isosecp256k1 struct {
 }
 
 // GetSECP256K1Isogeny returns a 3-degree isogeny from SECP256K1_3ISO to the SECP256K1 elliptic curve.
-func GetSECP256K1Isogeny() C.Isogeny {
-	e0 := SECP256K1_3ISO.Get()
-	e1 := SECP256K1.Get()
+func GetSECP256K1Isogeny() (C.Isogeny, error) {
+	e0, e1, err := getPair(SECP256K1_3ISO, SECP256K1)
+	if err != nil {
+		return nil, err
+	}
 	F := e0.Field()
 	return isosecp256k1{
 		E0: e0,
@@ -156,7 +177,7 @@ func GetSECP256K1Isogeny() C.Isogeny {
 			F.Elt("0x7a06534bb8bdb49fd5e9e6632722c2989467c1bfc8e8d978dfb425d2685c2573"),
 			F.Elt("0x6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f"),
 			F.One()},
-	}
+	}, nil
 }
 func (m isosecp256k1) String() string       { return fmt.Sprintf("3-Isogeny from %v to\n%v", m.E0, m.E1) }
 func (m isosecp256k1) Domain() C.EllCurve   { return m.E0 }
@@ -183,9 +204,11 @@ type isobls12381G1 struct {
 }
 
 // GetBLS12381G1Isogeny returns an 11-degree isogeny from BLS12381G1_11ISO to the BLS12381G1 elliptic curve.
-func GetBLS12381G1Isogeny() C.Isogeny {
-	e0 := BLS12381G1_11ISO.Get()
-	e1 := BLS12381G1.Get()
+func GetBLS12381G1Isogeny() (C.Isogeny, error) {
+	e0, e1, err := getPair(BLS12381G1_11ISO, BLS12381G1)
+	if err != nil {
+		return nil, err
+	}
 	F := e0.Field()
 	return isobls12381G1{
 		E0: e0,
@@ -250,7 +273,7 @@ func GetBLS12381G1Isogeny() C.Isogeny {
 			F.Elt("0x2660400eb2e4f3b628bdd0d53cd76f2bf565b94e72927c1cb748df27942480e420517bd8714cc80d1fadc1326ed06f7"),
 			F.Elt("0xe0fa1d816ddc03e6b24255e0d7819c171c40f65e273b853324efcd6356caa205ca2f570f13497804415473a1d634b8f"),
 			F.One()},
-	}
+	}, nil
 }
 func (m isobls12381G1) String() string       { return fmt.Sprintf("11-Isogeny from %v to\n%v", m.E0, m.E1) }
 func (m isobls12381G1) Domain() C.EllCurve   { return m.E0 }
@@ -280,9 +303,11 @@ type isobls12381G2 struct {
 }
 
 // GetBLS12381G2Isogeny returns an 3-degree isogeny from BLS12381G2_11ISO to the BLS12381G2 elliptic curve.
-func GetBLS12381G2Isogeny() C.Isogeny {
-	e0 := BLS12381G2_3ISO.Get()
-	e1 := BLS12381G2.Get()
+func GetBLS12381G2Isogeny() (C.Isogeny, error) {
+	e0, e1, err := getPair(BLS12381G2_3ISO, BLS12381G2)
+	if err != nil {
+		return nil, err
+	}
 	F := e0.Field()
 	return isobls12381G2{
 		E0: e0,
@@ -350,7 +375,7 @@ func GetBLS12381G2Isogeny() C.Isogeny {
 			}),
 			F.One(),
 		},
-	}
+	}, nil
 }
 func (m isobls12381G2) String() string       { return fmt.Sprintf("3-Isogeny from %v to\n%v", m.E0, m.E1) }
 func (m isobls12381G2) Domain() C.EllCurve   { return m.E0 }
@@ -373,3 +398,165 @@ func (m isobls12381G2) Push(p C.Point) C.Point {
 	yy = F.Mul(yy, y)
 	return m.E1.NewPoint(xx, yy)
 }
+
+type isobls12377G1 struct {
+	E0, E1                 C.EllCurve
+	xNum, xDen, yNum, yDen []GF.Elt
+}
+
+// GetBLS12377G1Isogeny returns a 2-degree isogeny from BLS12377G1_2ISO to the
+// BLS12377G1 elliptic curve. It is the isogeny used by gnark-crypto, whose
+// Z = 5 does not meet the criteria of Appendix H.2 of RFC 9380; hence, the
+// suites use instead Z = -11 as given by find_z_sswu.
+func GetBLS12377G1Isogeny() (C.Isogeny, error) {
+	e0, e1, err := getPair(BLS12377G1_2ISO, BLS12377G1)
+	if err != nil {
+		return nil, err
+	}
+	F := e0.Field()
+	return isobls12377G1{
+		E0: e0,
+		E1: e1,
+		xNum: []GF.Elt{
+			F.Elt("0x142abb491d3ccb00d65810beba93dbb0a661fd85974d6aa82c4bb2e1a3c84ffdd6ef419b80000000000000000000000"),
+			F.Elt("0x4d9d782ee8a7b7630cd57be9a2ca555e2f689a3cb86f60022910be6480000004284600000000001"),
+			F.Elt("0x142abb491d3ccb014ac44505178f6ec539a237640b7ceab573689a3cb86f600114885f32400000063c6900000000001"),
+			F.Zero(),
+		},
+		xDen: []GF.Elt{
+			F.Elt("0x13675e0bba29edd8c3355efa68b295578bda268f2e1bd8008a442f99200000010a11800000000004"),
+			F.One(),
+			F.Zero(),
+			F.Zero(),
+		},
+		yNum: []GF.Elt{
+			F.Elt("0x142abb491d3ccb014ac44505178f6ec539a237640b7ceab573689a3cb86f600114885f32400000063c68fffffffffff"),
+			F.Elt("0x35c748c2f8a21d6af848e30c1b78229a46644922460e73f6faf06c327b438084815848140000010a11800000000002"),
+			F.Elt("0xd71d230be288756a6446249c205dced645709767bd81c863eb7f8d8e4f15003f5f407b84000000a64af00000000002"),
+			F.Elt("0x17872fd54cc6ecd6d73a5085f0d2013b6de7eb4a0d6711d3b14f5e9c2c81f001429f19baa0000007467a80000000001"),
+		},
+		yDen: []GF.Elt{
+			F.Elt("0x1ae3a4617c510eac63b05c06ca1493b1a22d9f300f5138f1ef3622fba094800170b5d44300000008508bffffffffff9"),
+			F.Elt("0x746c34465cfb9314934039de742f800d471ce75b14a710033d991d96c00000063c6900000000000c"),
+			F.Elt("0x3a361a232e7dc98a49a01cef3a17c006a38e73ad8a5388019ecc8ecb600000031e3480000000000c"),
+			F.One(),
+		},
+	}, nil
+}
+func (m isobls12377G1) String() string       { return fmt.Sprintf("2-Isogeny from %v to\n%v", m.E0, m.E1) }
+func (m isobls12377G1) Domain() C.EllCurve   { return m.E0 }
+func (m isobls12377G1) Codomain() C.EllCurve { return m.E1 }
+func (m isobls12377G1) Push(p C.Point) C.Point {
+	F := m.E0.Field()
+	x, y := p.X(), p.Y()
+	xNum, xDen, yNum, yDen := F.Zero(), F.Zero(), F.Zero(), F.Zero()
+	for i := 3; i >= 0; i-- {
+		xNum = F.Add(F.Mul(xNum, x), m.xNum[i])
+		xDen = F.Add(F.Mul(xDen, x), m.xDen[i])
+		yNum = F.Add(F.Mul(yNum, x), m.yNum[i])
+		yDen = F.Add(F.Mul(yDen, x), m.yDen[i])
+	}
+	xx := F.Mul(xNum, F.Inv(xDen))
+	yy := F.Mul(yNum, F.Inv(yDen))
+	yy = F.Mul(yy, y)
+	return m.E1.NewPoint(xx, yy)
+}
+
+// isoPasta is a 3-isogeny to one of the Pasta curves, Pallas and Vesta, as
+// used by Zcash to hash to these curves.
+type isoPasta struct {
+	E0, E1                 C.EllCurve
+	xNum, xDen, yNum, yDen []GF.Elt
+}
+
+// GetPallasIsogeny returns a 3-degree isogeny from Pallas_3ISO to the Pallas elliptic curve.
+func GetPallasIsogeny() (C.Isogeny, error) {
+	e0, e1, err := getPair(Pallas_3ISO, Pallas)
+	if err != nil {
+		return nil, err
+	}
+	F := e0.Field()
+	return isoPasta{
+		E0: e0,
+		E1: e1,
+		xNum: []GF.Elt{
+			F.Elt("0x1c71c71c71c71c71c71c71c71c71c71c8102eea8e7b06eb6eebec06955555580"),
+			F.Elt("0x17329b9ec525375398c7d7ac3d98fd13380af066cfeb6d690eb64faef37ea4f7"),
+			F.Elt("0x3509afd51872d88e267c7ffa51cf412a0f93b82ee4b994958cf863b02814fb76"),
+			F.Elt("0x0e38e38e38e38e38e38e38e38e38e38e4081775473d8375b775f6034aaaaaaab"),
+		},
+		xDen: []GF.Elt{
+			F.Elt("0x325669becaecd5d11d13bf2a7f22b105b4abf9fb9a1fc81c2aa3af1eae5b6604"),
+			F.Elt("0x1d572e7ddc099cff5a607fcce0494a799c434ac1c96b6980c47f2ab668bcd71f"),
+			F.One(),
+			F.Zero(),
+		},
+		yNum: []GF.Elt{
+			F.Elt("0x025ed097b425ed097b425ed097b425ed0ac03e8e134eb3e493e53ab371c71c4f"),
+			F.Elt("0x3fb98ff0d2ddcadd303216cce1db9ff11765e924f745937802e2be87d225b234"),
+			F.Elt("0x1a84d7ea8c396c47133e3ffd28e7a09507c9dc17725cca4ac67c31d8140a7dbb"),
+			F.Elt("0x1a12f684bda12f684bda12f684bda12f7642b01ad461bad25ad985b5e38e38e4"),
+		},
+		yDen: []GF.Elt{
+			F.Elt("0x40000000000000000000000000000000224698fc094cf91b992d30ecfffffde5"),
+			F.Elt("0x17033d3c60c68173573b3d7f7d681310d976bbfabbc5661d4d90ab820b12320a"),
+			F.Elt("0x0c02c5bcca0e6b7f0790bfb3506defb65941a3a4a97aa1b35a28279b1d1b42ae"),
+			F.One(),
+		},
+	}, nil
+}
+
+// GetVestaIsogeny returns a 3-degree isogeny from Vesta_3ISO to the Vesta elliptic curve.
+func GetVestaIsogeny() (C.Isogeny, error) {
+	e0, e1, err := getPair(Vesta_3ISO, Vesta)
+	if err != nil {
+		return nil, err
+	}
+	F := e0.Field()
+	return isoPasta{
+		E0: e0,
+		E1: e1,
+		xNum: []GF.Elt{
+			F.Elt("0x31c71c71c71c71c71c71c71c71c71c71e1c521a795ac8356fb539a6f0000002b"),
+			F.Elt("0x18760c7f7a9ad20ded7ee4a9cdf78f8fd59d03d23b39cb11aeac67bbeb586a3d"),
+			F.Elt("0x1d935247b4473d17acecf10f5f7c09a2216b8861ec72bd5d8b95c6aaf703bcc5"),
+			F.Elt("0x38e38e38e38e38e38e38e38e38e38e390205dd51cfa0961a43cd42c800000001"),
+		},
+		xDen: []GF.Elt{
+			F.Elt("0x14735171ee5427780c621de8b91c242a30cd6d53df49d235f169c187d2533465"),
+			F.Elt("0x0a2de485568125d51454798a5b5c56b2a3ad678129b604d3b7284f7eaf21a2e9"),
+			F.One(),
+			F.Zero(),
+		},
+		yNum: []GF.Elt{
+			F.Elt("0x1ed097b425ed097b425ed097b425ed098bc32d36fb21a6a38f64842c55555533"),
+			F.Elt("0x19b0d87e16e2578866d1466e9de10e6497a3ca5c24e9ea634986913ab4443034"),
+			F.Elt("0x2ec9a923da239e8bd6767887afbe04d121d910aefb03b31d8bee58e5fb81de63"),
+			F.Elt("0x12f684bda12f684bda12f684bda12f685601f4709a8adcb36bef1642aaaaaaab"),
+		},
+		yDen: []GF.Elt{
+			F.Elt("0x40000000000000000000000000000000224698fc0994a8dd8c46eb20fffffde5"),
+			F.Elt("0x3d59f455cafc7668252659ba2b546c7e926847fb9ddd76a1d43d449776f99d2f"),
+			F.Elt("0x2f44d6c801c1b8bf9e7eb64f890a820c06a767bfc35b5bac58dfecce86b2745e"),
+			F.One(),
+		},
+	}, nil
+}
+func (m isoPasta) String() string       { return fmt.Sprintf("3-Isogeny from %v to\n%v", m.E0, m.E1) }
+func (m isoPasta) Domain() C.EllCurve   { return m.E0 }
+func (m isoPasta) Codomain() C.EllCurve { return m.E1 }
+func (m isoPasta) Push(p C.Point) C.Point {
+	F := m.E0.Field()
+	x, y := p.X(), p.Y()
+	xNum, xDen, yNum, yDen := F.Zero(), F.Zero(), F.Zero(), F.Zero()
+	for i := 3; i >= 0; i-- {
+		xNum = F.Add(F.Mul(xNum, x), m.xNum[i])
+		xDen = F.Add(F.Mul(xDen, x), m.xDen[i])
+		yNum = F.Add(F.Mul(yNum, x), m.yNum[i])
+		yDen = F.Add(F.Mul(yDen, x), m.yDen[i])
+	}
+	xx := F.Mul(xNum, F.Inv(xDen))
+	yy := F.Mul(yNum, F.Inv(yDen))
+	yy = F.Mul(yy, y)
+	return m.E1.NewPoint(xx, yy)
+}
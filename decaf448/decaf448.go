@@ -0,0 +1,198 @@
+// Package decaf448 implements the decaf448 prime-order group as specified in
+// RFC 9496. Elements are represented by points of edwards448, and two points
+// represent the same element if they differ by a point of order two.
+package decaf448
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/armfazh/h2c-go-ref/curve"
+	Q "github.com/armfazh/h2c-go-ref/internal/quotient"
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Lengths in bytes of encodings.
+const (
+	EncodingLen     = 56  // EncodingLen is the length of an encoded element.
+	UniformBytesLen = 112 // UniformBytesLen is the input length of FromUniformBytes.
+)
+
+// Errors returned when decoding elements.
+var (
+	ErrInvalidEncoding = errors.New("decaf448: invalid encoding")
+	ErrInvalidLength   = errors.New("decaf448: invalid length")
+)
+
+// Coordinates of the point represented by the canonical generator, whose
+// encoding is given in Appendix A.2 of RFC 9496.
+const (
+	generatorX = "0x55555555555555555555555555555555555555555555555555555555aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	generatorY = "0x51fa169cb528fb724ca629dfaf793d4ffc91285fca77b228481c928c75273b47f29a9a7cc5d5cf6744434d412e325f9425150432156c7912"
+)
+
+type params struct {
+	E                            C.T
+	F                            GF.Field
+	d, sqrtMinusD, invSqrtMinusD GF.Elt
+	oneMinusD, oneMinusTwoD      GF.Elt
+	pMinus3Div4                  *big.Int
+	generator                    C.Point
+}
+
+var d448 = newParams()
+
+func newParams() *params {
+	e, err := curve.Edwards448.Get()
+	if err != nil {
+		panic(err)
+	}
+	E := e.(C.T)
+	F := E.F
+	p := &params{E: E, F: F, d: E.D}
+	// The constants of Section 5.1 of RFC 9496 are derived from D.
+	p.sqrtMinusD = F.Sqrt(F.Neg(p.d))
+	if F.Sgn0(p.sqrtMinusD) == 1 {
+		p.sqrtMinusD = F.Neg(p.sqrtMinusD)
+	}
+	p.invSqrtMinusD = F.Inv(p.sqrtMinusD)
+	p.oneMinusD = F.Sub(F.One(), p.d)
+	p.oneMinusTwoD = F.Sub(p.oneMinusD, p.d)
+	p.pMinus3Div4 = new(big.Int).Rsh(F.P(), 2) // (p-3)/4, since p = 3 mod 4.
+	p.generator = E.NewPoint(F.Elt(generatorX), F.Elt(generatorY))
+	return p
+}
+
+// Curve returns edwards448, the curve whose points represent elements.
+func Curve() C.EllCurve { return d448.E }
+
+// sqrtRatioM1 returns (true, sqrt(u/v)) if u/v is square, and otherwise
+// (false, sqrt(-u/v)); the root is non-negative.
+func sqrtRatioM1(u, v GF.Elt) (bool, GF.Elt) {
+	F := d448.F
+	r := F.Mul(u, F.Exp(F.Mul(u, v), d448.pMinus3Div4))
+	check := F.Mul(v, F.Sqr(r))
+	return F.AreEqual(check, u), Q.Abs(F, r)
+}
+
+// Element is an element of the decaf448 group.
+type Element struct{ p C.Point }
+
+// NewElement returns the element represented by a point of edwards448. The
+// point must belong to the subgroup generated by the elements of the group,
+// e.g., a point returned by Point.
+func NewElement(p C.Point) *Element { return &Element{p.Copy()} }
+
+// Identity returns the identity element.
+func Identity() *Element { return &Element{d448.E.Identity()} }
+
+// Generator returns the canonical generator of the group.
+func Generator() *Element { return &Element{d448.generator.Copy()} }
+
+// Point returns a point of edwards448 that represents the element.
+func (e *Element) Point() C.Point { return e.p.Copy() }
+
+// Add returns e+f.
+func (e *Element) Add(f *Element) *Element { return &Element{d448.E.Add(e.p, f.p)} }
+
+// ScalarMult returns k*e.
+func (e *Element) ScalarMult(k *big.Int) *Element {
+	return &Element{d448.E.ScalarMult(e.p, k)}
+}
+
+// Equal returns true if both elements are equal, that is, if their
+// representatives differ by a point of order two.
+func (e *Element) Equal(f *Element) bool {
+	F := d448.F
+	return F.AreEqual(F.Mul(e.p.X(), f.p.Y()), F.Mul(e.p.Y(), f.p.X()))
+}
+
+// Encode returns the canonical encoding of the element.
+func (e *Element) Encode() []byte {
+	F := d448.F
+	// Affine coordinates, so Z = 1 and T = x*y.
+	x0, y0, z0 := e.p.X(), e.p.Y(), F.One()
+	t0 := F.Mul(x0, y0)
+
+	u1 := F.Mul(F.Add(x0, t0), F.Sub(x0, t0))
+	_, invsqrt := sqrtRatioM1(F.One(), F.Mul(F.Mul(u1, d448.oneMinusD), F.Sqr(x0)))
+	ratio := Q.Abs(F, F.Mul(F.Mul(invsqrt, u1), d448.sqrtMinusD))
+	u2 := F.Sub(F.Mul(F.Mul(d448.invSqrtMinusD, ratio), z0), t0)
+	s := Q.Abs(F, F.Mul(F.Mul(F.Mul(d448.oneMinusD, invsqrt), x0), u2))
+	return Q.FieldToBytes(s, EncodingLen)
+}
+
+// Decode returns the element encoded by b, or an error if b is not the
+// canonical encoding of an element.
+func Decode(b []byte) (*Element, error) {
+	if len(b) != EncodingLen {
+		return nil, ErrInvalidLength
+	}
+	F := d448.F
+	n := Q.BytesToInt(b)
+	if n.Cmp(F.P()) >= 0 {
+		return nil, ErrInvalidEncoding
+	}
+	s := F.Elt(n)
+	if Q.IsNegative(F, s) {
+		return nil, ErrInvalidEncoding
+	}
+	ss := F.Sqr(s)
+	u1 := F.Add(F.One(), ss)
+	u1Sqr := F.Sqr(u1)
+	u2 := F.Sub(u1Sqr, F.Mul(F.Mul(F.Elt(4), d448.d), ss))
+	wasSquare, invsqrt := sqrtRatioM1(F.One(), F.Mul(u2, u1Sqr))
+	u3 := Q.Abs(F, F.Mul(F.Mul(F.Mul(F.Add(s, s), invsqrt), u1), d448.sqrtMinusD))
+	x := F.Mul(F.Mul(F.Mul(u3, invsqrt), u2), d448.invSqrtMinusD)
+	y := F.Mul(F.Mul(F.Sub(F.One(), ss), invsqrt), u1)
+	if !wasSquare {
+		return nil, ErrInvalidEncoding
+	}
+	return &Element{d448.E.NewPoint(x, y)}, nil
+}
+
+// Map is the one-way map MAP of Section 5.3.4 of RFC 9496; it returns a
+// point of edwards448 that represents an element.
+func Map(t GF.Elt) C.Point {
+	F := d448.F
+	one := F.One()
+	r := F.Neg(F.Sqr(t))
+	u0 := F.Mul(d448.d, F.Sub(r, one))
+	u1 := F.Mul(F.Add(u0, one), F.Sub(u0, r))
+	wasSquare, v := sqrtRatioM1(d448.oneMinusTwoD, F.Mul(F.Add(r, one), u1))
+	vPrime, sgn := v, one
+	if !wasSquare {
+		vPrime, sgn = F.Mul(t, v), F.Neg(one)
+	}
+	s := F.Mul(vPrime, F.Add(r, one))
+	ss := F.Sqr(s)
+	w0 := F.Add(s, s)
+	if Q.IsNegative(F, s) == wasSquare {
+		w0 = F.Neg(w0) // 2*CT_ABS(s)*sgn
+	}
+	w1 := F.Add(one, ss)
+	w2 := F.Sub(one, ss)
+	w3 := F.Mul(F.Mul(F.Mul(vPrime, s), F.Sub(r, one)), d448.oneMinusTwoD)
+	w3 = F.Neg(F.Add(F.Mul(sgn, w3), one))
+	// The extended coordinates (w0*w3 : w2*w1 : w1*w3 : w0*w2) in affine form.
+	return d448.E.NewPoint(F.Mul(w0, F.Inv(w1)), F.Mul(w2, F.Inv(w3)))
+}
+
+// DecodeField returns the field element obtained from 56 bytes in
+// little-endian order, as used by FromUniformBytes.
+func DecodeField(b []byte) GF.Elt {
+	n := Q.BytesToInt(b)
+	return d448.F.Elt(n.Mod(n, d448.F.P()))
+}
+
+// FromUniformBytes maps 112 uniformly random bytes to an element following
+// Section 5.3.4 of RFC 9496.
+func FromUniformBytes(b []byte) (*Element, error) {
+	if len(b) != UniformBytesLen {
+		return nil, ErrInvalidLength
+	}
+	P1 := Map(DecodeField(b[:EncodingLen]))
+	P2 := Map(DecodeField(b[EncodingLen:]))
+	return &Element{d448.E.Add(P1, P2)}, nil
+}
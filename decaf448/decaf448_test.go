@@ -0,0 +1,148 @@
+package decaf448_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/armfazh/h2c-go-ref/decaf448"
+)
+
+type vectors struct {
+	Multiples        []string `json:"multiples"`
+	Invalid          []string `json:"invalid"`
+	FromUniformBytes []struct {
+		I string `json:"I"`
+		O string `json:"O"`
+	} `json:"fromUniformBytes"`
+}
+
+func readVectors(t *testing.T) (v vectors) {
+	b, err := ioutil.ReadFile("testdata/rfc9496.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestMultiples(t *testing.T) {
+	v := readVectors(t)
+	B := decaf448.Generator()
+	P := decaf448.Identity()
+	for i, s := range v.Multiples {
+		want := mustHex(t, s)
+		if got := P.Encode(); !bytes.Equal(got, want) {
+			t.Fatalf("%v*B\ngot:  %x\nwant: %x", i, got, want)
+		}
+		Q, err := decaf448.Decode(want)
+		if err != nil {
+			t.Fatalf("%v*B: %v", i, err)
+		}
+		if !Q.Equal(P) || !bytes.Equal(Q.Encode(), want) {
+			t.Fatalf("%v*B: decoding mismatch", i)
+		}
+		if R := B.ScalarMult(big.NewInt(int64(i))); !R.Equal(P) {
+			t.Fatalf("%v*B: scalar multiplication mismatch", i)
+		}
+		P = P.Add(B)
+	}
+}
+
+// TestInvalidEncodings checks the invalid encodings of RFC 9496 and one of
+// each case rejected by the decoding of Section 5.3.1: values that are not
+// reduced modulo p, negative values, and values s for which
+// (1+s^2)^2 - 4*D*s^2 is not a square.
+func TestInvalidEncodings(t *testing.T) {
+	for _, s := range readVectors(t).Invalid {
+		if _, err := decaf448.Decode(mustHex(t, s)); !errors.Is(err, decaf448.ErrInvalidEncoding) {
+			t.Fatalf("encoding: %v\ngot:  %v\nwant: %v", s, err, decaf448.ErrInvalidEncoding)
+		}
+	}
+	p := decaf448.Curve().Field().P()
+	for _, n := range []*big.Int{
+		p,
+		new(big.Int).Add(p, big.NewInt(1)),
+		big.NewInt(1),
+		new(big.Int).Sub(p, big.NewInt(2)),
+	} {
+		if _, err := decaf448.Decode(toBytes(n)); !errors.Is(err, decaf448.ErrInvalidEncoding) {
+			t.Fatalf("encoding: %x\ngot:  %v\nwant: %v", toBytes(n), err, decaf448.ErrInvalidEncoding)
+		}
+	}
+	// Even values below p are non-negative, so they decode to an element if
+	// and only if u2 = (1+s^2)^2 - 4*D*s^2 is a square, where D = -39081.
+	nonSquares := 0
+	for s := int64(0); s < 64; s += 2 {
+		u1 := big.NewInt(1 + s*s)
+		u2 := new(big.Int).Mul(u1, u1)
+		u2.Add(u2, big.NewInt(4*39081*s*s))
+		square := big.Jacobi(u2.Mod(u2, p), p) == 1
+		if !square {
+			nonSquares++
+		}
+		if _, err := decaf448.Decode(toBytes(big.NewInt(s))); square != (err == nil) {
+			t.Fatalf("s: %v square: %v\ngot: %v", s, square, err)
+		}
+	}
+	if nonSquares == 0 {
+		t.Fatal("no encoding with non-square u2 was tested")
+	}
+	if _, err := decaf448.Decode(make([]byte, 55)); !errors.Is(err, decaf448.ErrInvalidLength) {
+		t.Fatalf("got:  %v\nwant: %v", err, decaf448.ErrInvalidLength)
+	}
+}
+
+// toBytes encodes n in little-endian order as an encoding of decaf448.
+func toBytes(n *big.Int) []byte {
+	be := n.Bytes()
+	b := make([]byte, decaf448.EncodingLen)
+	for i := range be {
+		b[i] = be[len(be)-1-i]
+	}
+	return b
+}
+
+func TestFromUniformBytes(t *testing.T) {
+	for _, v := range readVectors(t).FromUniformBytes {
+		P, err := decaf448.FromUniformBytes(mustHex(t, v.I))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := P.Encode(), mustHex(t, v.O); !bytes.Equal(got, want) {
+			t.Fatalf("input: %v\ngot:  %x\nwant: %x", v.I, got, want)
+		}
+	}
+	if _, err := decaf448.FromUniformBytes(make([]byte, 56)); !errors.Is(err, decaf448.ErrInvalidLength) {
+		t.Fatalf("got:  %v\nwant: %v", err, decaf448.ErrInvalidLength)
+	}
+}
+
+// Representatives that differ by a point of order two are equal.
+func TestEqual(t *testing.T) {
+	E := decaf448.Curve()
+	F := E.Field()
+	T2 := E.NewPoint(F.Zero(), F.Elt(-1))
+	B := decaf448.Generator()
+	P := decaf448.NewElement(E.Add(B.Point(), T2))
+	if !P.Equal(B) || !bytes.Equal(P.Encode(), B.Encode()) {
+		t.Fatal("representatives of the same element are not equal")
+	}
+	if P.Equal(B.Add(B)) {
+		t.Fatal("different elements are equal")
+	}
+}
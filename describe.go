@@ -0,0 +1,231 @@
+package h2c
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	M "github.com/armfazh/h2c-go-ref/mapping"
+	C "github.com/armfazh/tozan-ecc/curve"
+)
+
+// SupportedSuites returns the identifiers of the registered suites in
+// lexicographic order.
+func SupportedSuites() []SuiteID {
+	suitesMu.RLock()
+	defer suitesMu.RUnlock()
+	ids := make([]SuiteID, 0, len(supportedSuitesID))
+	for id := range supportedSuitesID {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// SuiteDescriptor describes the parameters of a suite. Its JSON encoding
+// contains the same header fields used by the test vectors in testdata/suites;
+// Model, Order and Cofactor are not part of that encoding.
+type SuiteDescriptor struct {
+	ID       SuiteID
+	Curve    string   // Curve is the name of the curve.
+	Model    string   // Model is the form of the curve equation, e.g. "Weierstrass".
+	P        *big.Int // P is the characteristic of the field.
+	M        uint     // M is the extension degree of the field.
+	Order    *big.Int // Order is the order of the prime-order subgroup.
+	Cofactor *big.Int // Cofactor is the cofactor of the curve (1 for groups), not the h_eff of the suite.
+	K        uint     // K is the target security level in bits.
+	L        uint     // L is the length in bytes per field element.
+	Z        []*big.Int
+	Expander ExpanderType
+	Hash     string // Hash is the name of the hash function, e.g. "sha256" or "shake_256".
+	Map      string // Map is the name of the mapping, e.g. "SSWU".
+	RO       bool
+	DST      string // DST is only set for descriptors of test vectors.
+}
+
+// Describe returns a descriptor of the parameters of a registered suite, or
+// of a suite that can be assembled from its components.
+func (id SuiteID) Describe() (SuiteDescriptor, error) {
+	b, err := id.builder()
+	if err != nil {
+		return SuiteDescriptor{}, err
+	}
+	d, err := b.describe(id)
+	if err != nil {
+		return SuiteDescriptor{}, fmt.Errorf("Suite: %v: %w", id, err)
+	}
+	return d, nil
+}
+
+func (b SuiteBuilder) describe(id SuiteID) (d SuiteDescriptor, err error) {
+	e, err := b.build([]byte(id))
+	if err != nil {
+		return d, err
+	}
+	F := e.E.Field()
+	d = SuiteDescriptor{
+		ID:       id,
+		P:        F.P(),
+		M:        F.Ext(),
+		Order:    e.E.Order(),
+		Cofactor: e.E.Cofactor(),
+		K:        b.K,
+		L:        e.Field.L,
+		Map:      b.Map.ID.String(),
+		RO:       b.RO,
+	}
+	if e.Group {
+		// Elements of a prime-order group have no cofactor to clear.
+		d.Cofactor = big.NewInt(1)
+	}
+	d.Expander, d.Hash = b.Exp.vectorNames()
+	d.Curve, d.Model = curveName(e.E)
+	if c, err := id.Parse(); err == nil {
+		if k, ok := knownCurves[c.Curve]; ok && k.ID == b.E && b.Curve == nil {
+			d.Curve = k.Name
+		}
+	}
+	if z, ok := e.Mapping.(M.ZParameter); ok {
+		d.Z = z.GetZ().Polynomial()
+	}
+	return d, nil
+}
+
+func curveName(e C.EllCurve) (name, model string) {
+	switch E := e.(type) {
+	case C.W:
+		return E.Name, "Weierstrass"
+	case C.WC:
+		return E.Name, "WeierstrassC"
+	case C.M:
+		return E.Name, "Montgomery"
+	case C.T:
+		return E.Name, "TwistedEdwards"
+	default:
+		return fmt.Sprintf("%T", e), ""
+	}
+}
+
+// vectorNames returns the type of the expander and the name of its hash
+// function as used in test vectors.
+func (d ExpanderDesc) vectorNames() (ExpanderType, string) {
+	t, name := d.Variant()
+	return t, hashName(name)
+}
+
+// hashName returns the name of a hash function as used in test vectors.
+func hashName(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasPrefix(name, "sha3-"):
+		return strings.Replace(name, "-", "_", 1)
+	case strings.HasPrefix(name, "shake"):
+		return strings.Replace(name, "shake", "shake_", 1)
+	default:
+		return strings.NewReplacer("-", "", "/", "_").Replace(name)
+	}
+}
+
+type suiteHeader struct {
+	SuiteID string `json:"ciphersuite"`
+	Curve   string `json:"curve"`
+	DST     string `json:"dst,omitempty"`
+	Field   struct {
+		M string `json:"m"`
+		P string `json:"p"`
+	} `json:"field"`
+	Hash   string `json:"hash"`
+	K      string `json:"k"`
+	L      string `json:"L"`
+	Z      string `json:"Z"`
+	Expand string `json:"expand"`
+	Map    struct {
+		Name string `json:"name"`
+	} `json:"map"`
+	RandomOracle bool `json:"randomOracle"`
+}
+
+func toHex(n *big.Int) string { return "0x" + n.Text(16) }
+
+func fromHex(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	return n, nil
+}
+
+// MarshalJSON encodes the descriptor using the header fields of test vectors.
+func (d SuiteDescriptor) MarshalJSON() ([]byte, error) {
+	var h suiteHeader
+	h.SuiteID = string(d.ID)
+	h.Curve = d.Curve
+	h.DST = d.DST
+	h.Field.M = toHex(new(big.Int).SetUint64(uint64(d.M)))
+	if d.P != nil {
+		h.Field.P = toHex(d.P)
+	}
+	h.Hash = d.Hash
+	h.K = toHex(new(big.Int).SetUint64(uint64(d.K)))
+	h.L = toHex(new(big.Int).SetUint64(uint64(d.L)))
+	z := make([]string, len(d.Z))
+	for i := range d.Z {
+		z[i] = toHex(d.Z[i])
+	}
+	h.Z = strings.Join(z, ",")
+	h.Expand = d.Expander.String()
+	h.Map.Name = d.Map
+	h.RandomOracle = d.RO
+	return json.Marshal(h)
+}
+
+// UnmarshalJSON decodes the header fields of test vectors into the descriptor.
+func (d *SuiteDescriptor) UnmarshalJSON(b []byte) error {
+	var h suiteHeader
+	if err := json.Unmarshal(b, &h); err != nil {
+		return err
+	}
+	var v SuiteDescriptor
+	var err error
+	var m, k, l *big.Int
+	if m, err = fromHex(h.Field.M); err != nil {
+		return err
+	}
+	if v.P, err = fromHex(h.Field.P); err != nil {
+		return err
+	}
+	if k, err = fromHex(h.K); err != nil {
+		return err
+	}
+	if l, err = fromHex(h.L); err != nil {
+		return err
+	}
+	if h.Z != "" {
+		for _, s := range strings.Split(h.Z, ",") {
+			zi, err := fromHex(s)
+			if err != nil {
+				return err
+			}
+			v.Z = append(v.Z, zi)
+		}
+	}
+	switch h.Expand {
+	case XMD.String():
+		v.Expander = XMD
+	case XOF.String():
+		v.Expander = XOF
+	default:
+		v.Expander = OTHER
+	}
+	v.ID = SuiteID(h.SuiteID)
+	v.Curve = h.Curve
+	v.DST = h.DST
+	v.M, v.K, v.L = uint(m.Uint64()), uint(k.Uint64()), uint(l.Uint64())
+	v.Hash = h.Hash
+	v.Map = h.Map.Name
+	v.RO = h.RandomOracle
+	*d = v
+	return nil
+}
@@ -0,0 +1,65 @@
+package h2c_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+)
+
+func TestSupportedSuites(t *testing.T) {
+	supported := make(map[h2c.SuiteID]bool)
+	for _, id := range h2c.SupportedSuites() {
+		supported[id] = true
+	}
+	for _, id := range registeredSuites {
+		if !supported[id] {
+			t.Fatalf("suite: %v not listed", id)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	files, err := filepath.Glob("testdata/suites/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		byteValue, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want map[string]interface{}
+		if err := json.Unmarshal(byteValue, &want); err != nil {
+			t.Fatal(err)
+		}
+		delete(want, "vectors")
+
+		var v h2c.SuiteDescriptor
+		if err := json.Unmarshal(byteValue, &v); err != nil {
+			t.Fatal(err)
+		}
+		d, err := v.ID.Describe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Model == "" || d.Order == nil || d.Cofactor == nil {
+			t.Fatalf("suite: %v incomplete descriptor: %+v", d.ID, d)
+		}
+		d.DST = v.DST
+		desc, err := json.Marshal(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(desc, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("suite: %v\ngot:  %v\nwant: %v", d.ID, got, want)
+		}
+	}
+}
@@ -3,8 +3,13 @@ package h2c
 import (
 	"crypto"
 	"errors"
+	"fmt"
+	"hash"
 	"io"
 	"math"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/armfazh/h2c-go-ref/xof"
 )
@@ -22,39 +27,174 @@ const (
 	XMD ExpanderType = iota
 	// XOF denotes an expander based on an extendable output function.
 	XOF
-	// OTHER is reserved for a user-designed expander function (not implemented).
+	// OTHER denotes a user-designed expander function, see RegisterExpander.
 	OTHER
 )
 
+func (t ExpanderType) String() string {
+	switch t {
+	case XMD:
+		return "XMD"
+	case XOF:
+		return "XOF"
+	default:
+		return "OTHER"
+	}
+}
+
 // ExpanderDesc describes an expander
 type ExpanderDesc struct {
 	Type ExpanderType
-	ID   uint // This id is converted to either crypto.Hash or to xof.Xof
+	ID   uint // This id is converted to either crypto.Hash, to xof.Xof, or to a registered expander.
+}
+
+// ParseExpanderDesc returns the descriptor of an expander given its HASH_ID as
+// used in suite identifiers, e.g. "XMD:SHA-256" or "XOF:SHAKE128", or the
+// HASH_ID of an expander registered with RegisterExpander.
+func ParseExpanderDesc(hashID string) (ExpanderDesc, error) {
+	if id, ok := lookupExpanderName(hashID); ok {
+		return ExpanderDesc{OTHER, id}, nil
+	}
+	return parseBuiltinExpander(hashID)
+}
+
+func parseBuiltinExpander(hashID string) (ExpanderDesc, error) {
+	tag := strings.SplitN(hashID, ":", 2)
+	if len(tag) == 2 {
+		switch tag[0] {
+		case XMD.String():
+			for h := crypto.MD4; h <= crypto.BLAKE2b_512; h++ {
+				if h.String() == tag[1] {
+					return ExpanderDesc{XMD, uint(h)}, nil
+				}
+			}
+		case XOF.String():
+			if x, err := xof.ParseXOF(tag[1]); err == nil {
+				return ExpanderDesc{XOF, uint(x)}, nil
+			}
+		}
+	}
+	return ExpanderDesc{}, fmt.Errorf("expander %v not supported", hashID)
+}
+
+// String returns the HASH_ID of the expander, e.g. "XMD:SHA-256".
+func (d ExpanderDesc) String() string {
+	if d.Type == OTHER {
+		return d.HashName()
+	}
+	return d.Type.String() + ":" + d.HashName()
 }
 
+// HashName returns the name of the hash function used by the expander. For
+// registered expanders, it is the HASH_ID given to RegisterExpander.
+func (d ExpanderDesc) HashName() string {
+	switch d.Type {
+	case OTHER:
+		if r, ok := lookupExpander(d.ID); ok {
+			return r.hashID
+		}
+	case XMD:
+		return crypto.Hash(d.ID).String()
+	case XOF:
+		if x := xof.XofID(d.ID); x.Available() {
+			return x.String()
+		}
+	}
+	return "#" + strconv.Itoa(int(d.ID))
+}
+
+// Variant returns the type of the expander and the name of its hash function.
+// A registered expander whose HASH_ID starts with "XMD:" or "XOF:" is a
+// variant of that type, so its type and hash name are read from the HASH_ID,
+// e.g. XMD and "BLAKE2b" for XMDBLAKE2bZcash. Otherwise, it returns d.Type and
+// d.HashName().
+func (d ExpanderDesc) Variant() (ExpanderType, string) {
+	if d.Type == OTHER {
+		name := d.HashName()
+		for _, t := range []ExpanderType{XMD, XOF} {
+			if prefix := t.String() + ":"; strings.HasPrefix(name, prefix) {
+				return t, strings.TrimPrefix(name, prefix)
+			}
+		}
+	}
+	return d.Type, d.HashName()
+}
+
+// Errors returned when expanding a message.
+var (
+	ErrInvalidDST     = errors.New("expander: DST must be non-empty")
+	ErrDSTTooLong     = errors.New("expander: DST_prime is longer than 256 bytes")
+	ErrLengthTooLarge = errors.New("expander: len_in_bytes is larger than 65535")
+	ErrEllTooLarge    = errors.New("expander: ell is larger than 255")
+)
+
 // Get returns an XOF-based expander.
 func (d ExpanderDesc) Get(dst []byte, k uint) (e Expander, err error) {
+	if len(dst) == 0 {
+		return nil, ErrInvalidDST
+	}
 	switch d.Type {
 	case XMD:
-		e = &expanderXMD{dst, crypto.Hash(d.ID)}
+		if !crypto.Hash(d.ID).Available() {
+			return nil, errors.New("hash function not available")
+		}
+		return NewExpanderXMD(crypto.Hash(d.ID).New, dst, k)
 	case XOF:
-		e = &expanderXOF{dst, xof.XofID(d.ID), k}
-	default:
-		return nil, errors.New("expander not supported")
+		if !xof.XofID(d.ID).Available() {
+			return nil, errors.New("xof function not available")
+		}
+		if s := xof.XofID(d.ID).SecurityLevel(); s < k {
+			return nil, fmt.Errorf("%w: %v provides %v bits, want %v", ErrInvalidSecurityLevel, xof.XofID(d.ID), s, k)
+		}
+		x := &expanderXOF{dst: dst, id: xof.XofID(d.ID), k: k}
+		x.pool.New = func() interface{} { return x.id.New() }
+		return x, x.constructDSTPrime()
+	case OTHER:
+		if r, ok := lookupExpander(d.ID); ok {
+			return r.new(dst, k)
+		}
 	}
-	return e, e.constructDSTPrime()
+	return nil, errors.New("expander not supported")
 }
 
-// Expander allows to generate a pseudo-random byte string of a determined length.
+// Expander allows to generate a pseudo-random byte string of a determined
+// length. User-designed expanders can implement it directly, or through
+// ExpandFunc.
 type Expander interface {
-	constructDSTPrime() error
+	// Expand is the same as ExpandMessage but it panics on error.
 	Expand(in []byte, len uint) (pseudo []byte)
+	// ExpandMessage returns a pseudo-random byte string of len bytes, or an
+	// error if len is too large for the expander.
+	ExpandMessage(in []byte, len uint) (pseudo []byte, err error)
+	// NewHasher returns an ExpandHasher that absorbs the input incrementally
+	// and produces the same output as Expand.
+	NewHasher() ExpandHasher
+}
+
+// TraceExpander is implemented by the expanders of this package to expose
+// their intermediate values, which are included in the test vectors.
+type TraceExpander interface {
+	Expander
+	// DSTPrime returns the domain separation tag used by the expander.
+	DSTPrime() []byte
+	// ExpandWithTrace is the same as ExpandMessage but it also returns the
+	// intermediate values computed while expanding.
+	ExpandWithTrace(in []byte, len uint) (*ExpandTrace, error)
+}
+
+// ExpandTrace holds the intermediate values computed while expanding a message.
+type ExpandTrace struct {
+	DSTPrime     []byte   // DSTPrime is DST_prime.
+	MsgPrime     []byte   // MsgPrime is msg_prime, the input of the first hash call.
+	B            [][]byte // B are the blocks b_0, ..., b_ell; only set by XMD.
+	UniformBytes []byte   // UniformBytes is the output of the expander.
 }
 
 type expanderXOF struct {
-	dst []byte
-	id  xof.XofID
-	k   uint
+	dst  []byte
+	id   xof.XofID
+	k    uint
+	pool sync.Pool // pool reuses the states of the XOF across calls.
 }
 
 func (e *expanderXOF) constructDSTPrime() (err error) {
@@ -69,34 +209,116 @@ func (e *expanderXOF) constructDSTPrime() (err error) {
 	return err
 }
 
-func (e *expanderXOF) Expand(msg []byte, n uint) []byte {
-	if n > math.MaxUint16 || len(e.dst) > math.MaxUint8 {
-		panic(errors.New("requested too many bytes"))
+func (e *expanderXOF) Expand(msg []byte, n uint) []byte { return mustExpand(e.ExpandMessage(msg, n)) }
+func (e *expanderXOF) ExpandMessage(msg []byte, n uint) ([]byte, error) {
+	H := e.pool.Get().(xof.XOF)
+	defer func() { H.Reset(); e.pool.Put(H) }()
+	_, _ = H.Write(msg)
+	return e.finish(H, n)
+}
+
+// finish completes the expansion of a message already absorbed by H.
+func (e *expanderXOF) finish(H xof.XOF, n uint) ([]byte, error) {
+	if n > math.MaxUint16 {
+		return nil, ErrLengthTooLarge
+	}
+	if len(e.dst) > math.MaxUint8+1 {
+		return nil, ErrDSTTooLong
 	}
 	bLen := []byte{0, 0}
 	bLen[0] = byte((n >> 8) & 0xFF)
 	bLen[1] = byte(n & 0xFF)
 	pseudo := make([]byte, n)
 
-	H := e.id.New()
-	_, _ = H.Write(msg)
 	_, _ = H.Write(bLen)
 	_, _ = H.Write(e.dst)
-	_, err := io.ReadFull(H, pseudo)
-	if err != nil {
-		panic(err)
+	if _, err := io.ReadFull(H, pseudo); err != nil {
+		return nil, err
 	}
-	return pseudo
+	return pseudo, nil
+}
+
+// DSTPrime returns DST_prime, that is, DST || I2OSP(len(DST), 1), where DST
+// is hashed first if it is longer than 255 bytes.
+func (e *expanderXOF) DSTPrime() []byte { return append([]byte{}, e.dst...) }
+
+// ExpandWithTrace is the same as ExpandMessage but it also returns msg_prime.
+func (e *expanderXOF) ExpandWithTrace(msg []byte, n uint) (*ExpandTrace, error) {
+	t := &ExpandTrace{DSTPrime: e.DSTPrime()}
+	t.MsgPrime = make([]byte, 0, len(msg)+2+len(e.dst))
+	t.MsgPrime = append(t.MsgPrime, msg...)
+	t.MsgPrime = append(t.MsgPrime, byte(n>>8), byte(n))
+	t.MsgPrime = append(t.MsgPrime, e.dst...)
+	var err error
+	if t.UniformBytes, err = e.ExpandMessage(msg, n); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (e *expanderXOF) NewHasher() ExpandHasher {
+	return &xofHasher{e: e, H: e.id.New()}
+}
+
+type xofHasher struct {
+	e *expanderXOF
+	H xof.XOF
+}
+
+func (h *xofHasher) Write(p []byte) (int, error) { return h.H.Write(p) }
+func (h *xofHasher) Reset()                      { h.H.Reset() }
+func (h *xofHasher) Sum(n uint) ([]byte, error)  { return h.e.finish(h.H.Clone(), n) }
+
+// Errors returned when a hash function cannot be used by expand_message_xmd.
+var (
+	ErrHashSize  = errors.New("expander: hash output is shorter than 2k bits")
+	ErrBlockSize = errors.New("expander: hash block size is smaller than its output size")
+)
+
+// NewExpanderXMD returns an expand_message_xmd expander based on any hash
+// function, e.g. Keccak-256 or SM3. The output of the hash function must have
+// at least 2k bits, and its block size, which determines Z_pad, must not be
+// smaller than its output size.
+func NewExpanderXMD(h func() hash.Hash, dst []byte, k uint) (Expander, error) {
+	if len(dst) == 0 {
+		return nil, ErrInvalidDST
+	}
+	H := h()
+	if uint(H.Size())*8 < 2*k {
+		return nil, fmt.Errorf("%w: got %v bits want at least %v", ErrHashSize, H.Size()*8, 2*k)
+	}
+	if H.BlockSize() < H.Size() {
+		return nil, fmt.Errorf("%w: got %v bytes want at least %v", ErrBlockSize, H.BlockSize(), H.Size())
+	}
+	e := &expanderXMD{dst: dst, h: h}
+	if s, ok := H.(binaryState); ok {
+		_, _ = H.Write(make([]byte, H.BlockSize()))
+		if state, err := s.MarshalBinary(); err == nil {
+			e.zPad = state
+		}
+	}
+	return e, e.constructDSTPrime()
 }
 
 type expanderXMD struct {
-	dst []byte
-	id  crypto.Hash
+	dst  []byte
+	h    func() hash.Hash
+	zPad []byte // zPad is the state of the hash after absorbing Z_pad, if it can be copied.
+}
+
+// pad sets H to the state after absorbing Z_pad. It restores the state saved
+// by NewExpanderXMD, so Z_pad is hashed only once per expander.
+func (e *expanderXMD) pad(H hash.Hash) {
+	if s, ok := H.(binaryState); ok && e.zPad != nil && s.UnmarshalBinary(e.zPad) == nil {
+		return
+	}
+	H.Reset()
+	_, _ = H.Write(make([]byte, H.BlockSize()))
 }
 
 func (e *expanderXMD) constructDSTPrime() error {
 	if len(e.dst) > MaxDSTLength {
-		H := e.id.New()
+		H := e.h()
 		_, _ = H.Write(_LongDSTPrefix[:])
 		_, _ = H.Write(e.dst)
 		e.dst = H.Sum(nil)
@@ -105,22 +327,37 @@ func (e *expanderXMD) constructDSTPrime() error {
 	return nil
 }
 
-func (e *expanderXMD) Expand(msg []byte, n uint) []byte {
-	H := e.id.New()
+func (e *expanderXMD) Expand(msg []byte, n uint) []byte { return mustExpand(e.ExpandMessage(msg, n)) }
+func (e *expanderXMD) ExpandMessage(msg []byte, n uint) ([]byte, error) {
+	H := e.h()
+	e.pad(H)
+	_, _ = H.Write(msg)
+	return e.finish(H, n)
+}
+
+// finish completes the expansion of a message already absorbed by H, that
+// is, H has absorbed Z_pad || msg.
+func (e *expanderXMD) finish(H hash.Hash, n uint) ([]byte, error) { return e.finishTrace(H, n, nil) }
+
+// finishTrace is the same as finish, but it also appends b_0, ..., b_ell to
+// blocks if it is not nil.
+func (e *expanderXMD) finishTrace(H hash.Hash, n uint, blocks *[][]byte) ([]byte, error) {
 	bLen := uint(H.Size())
 	ell := (n + (bLen - 1)) / bLen
-	if ell > math.MaxUint8 || n > math.MaxUint16 || len(e.dst) > math.MaxUint8 {
-		panic(errors.New("requested too many bytes"))
+	if n > math.MaxUint16 {
+		return nil, ErrLengthTooLarge
+	}
+	if ell > math.MaxUint8 {
+		return nil, fmt.Errorf("%w: ell=%v", ErrEllTooLarge, ell)
+	}
+	if len(e.dst) > math.MaxUint8+1 {
+		return nil, ErrDSTTooLong
 	}
 
-	zPad := make([]byte, H.BlockSize())
 	libStr := []byte{0, 0}
 	libStr[0] = byte((n >> 8) & 0xFF)
 	libStr[1] = byte(n & 0xFF)
 
-	H.Reset()
-	_, _ = H.Write(zPad)
-	_, _ = H.Write(msg)
 	_, _ = H.Write(libStr)
 	_, _ = H.Write([]byte{0})
 	_, _ = H.Write(e.dst)
@@ -132,6 +369,9 @@ func (e *expanderXMD) Expand(msg []byte, n uint) []byte {
 	_, _ = H.Write(e.dst)
 	bi := H.Sum(nil)
 	pseudo := append([]byte{}, bi...)
+	if blocks != nil {
+		*blocks = append(*blocks, b0, append([]byte{}, bi...))
+	}
 	for i := uint(2); i <= ell; i++ {
 		H.Reset()
 		_, _ = H.Write(xor(bi, b0))
@@ -139,8 +379,91 @@ func (e *expanderXMD) Expand(msg []byte, n uint) []byte {
 		_, _ = H.Write(e.dst)
 		bi = H.Sum(nil)
 		pseudo = append(pseudo, bi...)
+		if blocks != nil {
+			*blocks = append(*blocks, append([]byte{}, bi...))
+		}
 	}
-	return pseudo[0:n]
+	return pseudo[0:n], nil
+}
+
+// DSTPrime returns DST_prime, that is, DST || I2OSP(len(DST), 1), where DST
+// is hashed first if it is longer than 255 bytes.
+func (e *expanderXMD) DSTPrime() []byte { return append([]byte{}, e.dst...) }
+
+// ExpandWithTrace is the same as ExpandMessage but it also returns msg_prime
+// and the blocks b_0, ..., b_ell.
+func (e *expanderXMD) ExpandWithTrace(msg []byte, n uint) (*ExpandTrace, error) {
+	H := e.h()
+	t := &ExpandTrace{DSTPrime: e.DSTPrime()}
+	t.MsgPrime = make([]byte, H.BlockSize(), H.BlockSize()+len(msg)+3+len(e.dst))
+	t.MsgPrime = append(t.MsgPrime, msg...)
+	t.MsgPrime = append(t.MsgPrime, byte(n>>8), byte(n), 0)
+	t.MsgPrime = append(t.MsgPrime, e.dst...)
+	e.pad(H)
+	_, _ = H.Write(msg)
+	var err error
+	t.UniformBytes, err = e.finishTrace(H, n, &t.B)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (e *expanderXMD) NewHasher() ExpandHasher {
+	h := &xmdHasher{e: e, H: e.h()}
+	_, h.clone = h.H.(binaryState)
+	h.Reset()
+	return h
+}
+
+// binaryState is implemented by hash functions whose state can be copied.
+type binaryState interface {
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary([]byte) error
+}
+
+// xmdHasher absorbs Z_pad || msg into H. If the state of H cannot be copied,
+// the message is buffered instead and expanded at once by Sum.
+type xmdHasher struct {
+	e     *expanderXMD
+	H     hash.Hash
+	clone bool
+	msg   []byte
+}
+
+func (h *xmdHasher) Write(p []byte) (int, error) {
+	if !h.clone {
+		h.msg = append(h.msg, p...)
+		return len(p), nil
+	}
+	return h.H.Write(p)
+}
+
+func (h *xmdHasher) Reset() {
+	h.msg = h.msg[:0]
+	h.e.pad(h.H)
+}
+
+func (h *xmdHasher) Sum(n uint) ([]byte, error) {
+	if !h.clone {
+		return h.e.ExpandMessage(h.msg, n)
+	}
+	state, err := h.H.(binaryState).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	H := h.e.h()
+	if err := H.(binaryState).UnmarshalBinary(state); err != nil {
+		return nil, err
+	}
+	return h.e.finish(H, n)
+}
+
+func mustExpand(pseudo []byte, err error) []byte {
+	if err != nil {
+		panic(err)
+	}
+	return pseudo
 }
 
 func xor(x, y []byte) []byte {
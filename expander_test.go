@@ -3,15 +3,21 @@ package h2c
 import (
 	"bytes"
 	"crypto"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"hash"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strconv"
 	"testing"
 
+	"github.com/armfazh/h2c-go-ref/curve"
+	M "github.com/armfazh/h2c-go-ref/mapping"
 	"github.com/armfazh/h2c-go-ref/xof"
+	"golang.org/x/crypto/sha3"
 )
 
 type expandMsgVector struct {
@@ -22,7 +28,7 @@ type expandMsgVector struct {
 	Vectors []struct {
 		LenInBytes   string `json:"len_in_bytes"`
 		DSTPrime     string `json:"DST_prime"`
-		MsgPrime     string `json:"ms_prime"`
+		MsgPrime     string `json:"msg_prime"`
 		UniformBytes string `json:"uniform_bytes"`
 		Msg          string `json:"msg"`
 	} `json:"tests"`
@@ -39,8 +45,14 @@ func (v expandMsgVector) test(t *testing.T) {
 		expID = ExpanderDesc{XOF, uint(xof.SHAKE128)}
 	case "SHAKE256":
 		expID = ExpanderDesc{XOF, uint(xof.SHAKE256)}
+	case "BLAKE2b":
+		expID = ExpanderDesc{OTHER, XMDBLAKE2bZcash}
 	default:
-		t.Fatal("Expander not supported")
+		id, err := xof.ParseXOF(v.Hash)
+		if v.Name != "expand_message_xof" || err != nil {
+			t.Fatal("Expander not supported")
+		}
+		expID = ExpanderDesc{XOF, uint(id)}
 	}
 	exp, err := expID.Get([]byte(v.DST), v.K)
 	if err != nil {
@@ -59,6 +71,36 @@ func (v expandMsgVector) test(t *testing.T) {
 		if !bytes.Equal(got, want) {
 			t.Fatalf("suite: %v\ngot:  %v\nwant: %v", v.Hash, got, want)
 		}
+		tr, err := exp.(TraceExpander).ExpandWithTrace([]byte(v.Vectors[i].Msg), uint(len))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(exp.(TraceExpander).DSTPrime()); got != v.Vectors[i].DSTPrime {
+			t.Fatalf("suite: %v DST_prime\ngot:  %v\nwant: %v", v.Hash, got, v.Vectors[i].DSTPrime)
+		}
+		if got := hex.EncodeToString(tr.MsgPrime); got != v.Vectors[i].MsgPrime {
+			t.Fatalf("suite: %v msg_prime\ngot:  %v\nwant: %v", v.Hash, got, v.Vectors[i].MsgPrime)
+		}
+		if !bytes.Equal(tr.UniformBytes, want) {
+			t.Fatalf("suite: %v trace\ngot:  %x\nwant: %x", v.Hash, tr.UniformBytes, want)
+		}
+		if expID.Type == XMD {
+			H := crypto.Hash(expID.ID).New()
+			_, _ = H.Write(tr.MsgPrime)
+			if b0 := H.Sum(nil); !bytes.Equal(tr.B[0], b0) {
+				t.Fatalf("suite: %v b_0\ngot:  %x\nwant: %x", v.Hash, tr.B[0], b0)
+			}
+			if got := bytes.Join(tr.B[1:], nil)[:len]; !bytes.Equal(got, want) {
+				t.Fatalf("suite: %v b_1 || ... || b_ell\ngot:  %x\nwant: %x", v.Hash, got, want)
+			}
+		}
+		h := exp.NewHasher()
+		for _, c := range []byte(v.Vectors[i].Msg) {
+			_, _ = h.Write([]byte{c})
+		}
+		if got, err := h.Sum(uint(len)); err != nil || !bytes.Equal(got, want) {
+			t.Fatalf("suite: %v hasher\ngot:  %v\nwant: %v", v.Hash, got, want)
+		}
 	}
 }
 
@@ -92,3 +134,176 @@ func TestExpander(t *testing.T) {
 		t.Fatalf("error on reading testdata folder: %v", errFolder)
 	}
 }
+
+func TestExpanderErrors(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander")
+	sha256 := ExpanderDesc{XMD, uint(crypto.SHA256)}
+	shake128 := ExpanderDesc{XOF, uint(xof.SHAKE128)}
+	if _, err := sha256.Get(nil, 128); !errors.Is(err, ErrInvalidDST) {
+		t.Fatalf("got:  %v\nwant: %v", err, ErrInvalidDST)
+	}
+	for _, v := range []struct {
+		desc ExpanderDesc
+		n    uint
+		err  error
+	}{
+		{sha256, 255 * 32, nil},
+		{sha256, 255*32 + 1, ErrEllTooLarge},
+		{sha256, 65536, ErrLengthTooLarge},
+		{shake128, 65535, nil},
+		{shake128, 65536, ErrLengthTooLarge},
+	} {
+		exp, err := v.desc.Get(dst, 128)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := exp.ExpandMessage(nil, v.n); !errors.Is(err, v.err) {
+			t.Fatalf("expander: %v len: %v\ngot:  %v\nwant: %v", v.desc, v.n, err, v.err)
+		}
+		if _, err := exp.NewHasher().Sum(v.n); !errors.Is(err, v.err) {
+			t.Fatalf("expander: %v len: %v hasher\ngot:  %v\nwant: %v", v.desc, v.n, err, v.err)
+		}
+	}
+
+	// hash_to_field requests at least 8192 bytes, so ell is larger than 255.
+	b := SuiteBuilder{E: curve.P256, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -10}, L: 8192, RO: true}
+	hashToCurve, err := b.Get(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hashToCurve.HashWithTrace(nil); !errors.Is(err, ErrEllTooLarge) {
+		t.Fatalf("got:  %v\nwant: %v", err, ErrEllTooLarge)
+	}
+	if _, err := hashToCurve.NewHasher().Sum(); !errors.Is(err, ErrEllTooLarge) {
+		t.Fatalf("got:  %v\nwant: %v", err, ErrEllTooLarge)
+	}
+	if _, err := hashToCurve.GetHashToScalar().NewHasher().Sum(); !errors.Is(err, ErrEllTooLarge) {
+		t.Fatalf("got:  %v\nwant: %v", err, ErrEllTooLarge)
+	}
+}
+
+type shortBlock struct{ hash.Hash }
+
+func (shortBlock) BlockSize() int { return 16 }
+
+func TestExpanderXMDHash(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-KECCAK256-128")
+	msg := []byte("abc")
+	exp, err := NewExpanderXMD(sha3.NewLegacyKeccak256, dst, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// For 32 bytes, uniform_bytes = H(H(Z_pad || msg || 0x0020 || 0x00 || DST_prime) || 0x01 || DST_prime).
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+	H := sha3.NewLegacyKeccak256()
+	_, _ = H.Write(make([]byte, H.BlockSize()))
+	_, _ = H.Write(msg)
+	_, _ = H.Write([]byte{0x00, 0x20, 0x00})
+	_, _ = H.Write(dstPrime)
+	b0 := H.Sum(nil)
+	H.Reset()
+	_, _ = H.Write(b0)
+	_, _ = H.Write([]byte{0x01})
+	_, _ = H.Write(dstPrime)
+	want := H.Sum(nil)
+	if got := exp.Expand(msg, 32); !bytes.Equal(got, want) {
+		t.Fatalf("got:  %x\nwant: %x", got, want)
+	}
+	h := exp.NewHasher()
+	_, _ = h.Write(msg)
+	if got, err := h.Sum(32); err != nil || !bytes.Equal(got, want) {
+		t.Fatalf("got:  %x\nwant: %x", got, want)
+	}
+
+	for _, v := range []struct {
+		h   func() hash.Hash
+		k   uint
+		err error
+	}{
+		{sha256.New, 256, ErrHashSize},
+		{func() hash.Hash { return shortBlock{sha256.New()} }, 128, ErrBlockSize},
+	} {
+		if _, err := NewExpanderXMD(v.h, dst, v.k); !errors.Is(err, v.err) {
+			t.Fatalf("got:  %v\nwant: %v", err, v.err)
+		}
+	}
+}
+
+func BenchmarkExpander(b *testing.B) {
+	dst := []byte("QUUX-V01-CS02-with-expander")
+	msg := make([]byte, 64)
+	const n = 128
+	get := func(d ExpanderDesc, k uint) Expander {
+		exp, err := d.Get(dst, k)
+		if err != nil {
+			b.Fatal(err)
+		}
+		return exp
+	}
+	// noZPad hashes Z_pad on every call, as done before caching its state.
+	noZPad := func(exp Expander) Expander {
+		e := exp.(*expanderXMD)
+		return &expanderXMD{dst: e.dst, h: e.h}
+	}
+	// noPool allocates a new XOF on every call.
+	noPool := func(exp Expander) Expander {
+		e := exp.(*expanderXOF)
+		return ExpandFunc(func(msg []byte, n uint) ([]byte, error) {
+			H := e.id.New()
+			_, _ = H.Write(msg)
+			return e.finish(H, n)
+		})
+	}
+	sha256 := get(ExpanderDesc{XMD, uint(crypto.SHA256)}, 128)
+	sha512 := get(ExpanderDesc{XMD, uint(crypto.SHA512)}, 256)
+	shake128 := get(ExpanderDesc{XOF, uint(xof.SHAKE128)}, 128)
+	shake256 := get(ExpanderDesc{XOF, uint(xof.SHAKE256)}, 256)
+	for _, v := range []struct {
+		name string
+		exp  Expander
+	}{
+		{"XMD:SHA-256/cached", sha256},
+		{"XMD:SHA-256/uncached", noZPad(sha256)},
+		{"XMD:SHA-512/cached", sha512},
+		{"XMD:SHA-512/uncached", noZPad(sha512)},
+		{"XOF:SHAKE128/cached", shake128},
+		{"XOF:SHAKE128/uncached", noPool(shake128)},
+		{"XOF:SHAKE256/cached", shake256},
+		{"XOF:SHAKE256/uncached", noPool(shake256)},
+	} {
+		exp := v.exp
+		b.Run(v.name, func(b *testing.B) {
+			b.SetBytes(int64(len(msg)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				exp.Expand(msg, n)
+			}
+		})
+	}
+}
+
+func TestExpanderSP800185(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SP800-185")
+	msg := make([]byte, 3*xof.ParallelHashBlockSize/2)
+	for _, id := range []xof.XofID{xof.TUPLEHASH128, xof.TUPLEHASH256, xof.PARALLELHASH128, xof.PARALLELHASH256} {
+		exp, err := ExpanderDesc{XOF, uint(id)}.Get(dst, id.SecurityLevel())
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := exp.Expand(msg, 0x80)
+		h := exp.NewHasher()
+		for i := 0; i < len(msg); i += 1000 {
+			end := i + 1000
+			if end > len(msg) {
+				end = len(msg)
+			}
+			_, _ = h.Write(msg[i:end])
+		}
+		if got, err := h.Sum(0x80); err != nil || !bytes.Equal(got, want) {
+			t.Fatalf("xof: %v\ngot:  %x\nwant: %x", id, got, want)
+		}
+		if got := exp.Expand(msg, 0x80); !bytes.Equal(got, want) {
+			t.Fatalf("xof: %v is not deterministic", id)
+		}
+	}
+}
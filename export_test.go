@@ -0,0 +1,5 @@
+package h2c
+
+// Unregister removes a suite registered by a test, so the test can run
+// several times in the same process.
+func (id SuiteID) Unregister() { id.unregister() }
@@ -1,6 +1,14 @@
 package field
 
-import F "github.com/armfazh/tozan-ecc/field"
+import (
+	"errors"
+	"fmt"
+
+	F "github.com/armfazh/tozan-ecc/field"
+)
+
+// ErrUnsupported is returned when a field identifier is not known.
+var ErrUnsupported = errors.New("field: not supported")
 
 // ID is an identifier of a well-known finite field.
 type ID string
@@ -14,28 +22,47 @@ const (
 	P521       ID = "2^521-1"
 	BLS12381G1 ID = "BLS12381G1"
 	BLS12381G2 ID = "BLS12381G2"
+	BN254G1    ID = "BN254G1"
+	BN254G2    ID = "BN254G2"
+	BLS12377G1 ID = "BLS12377G1"
+	BLS12377G2 ID = "BLS12377G2"
+	Pallas     ID = "Pallas"
+	Vesta      ID = "Vesta"
 )
 
-// Get returns an implementation of a field corresponding to the identifier.
-func (id ID) Get() F.Field {
+// Get returns an implementation of a field corresponding to the identifier,
+// otherwise returns an error if the identifier is not supported.
+func (id ID) Get() (F.Field, error) {
 	switch id {
 	case P25519:
-		return F.NewFp(string(id), "57896044618658097711785492504343953926634992332820282019728792003956564819949")
+		return F.NewFp(string(id), "57896044618658097711785492504343953926634992332820282019728792003956564819949"), nil
 	case P256:
-		return F.NewFp(string(id), "115792089210356248762697446949407573530086143415290314195533631308867097853951")
+		return F.NewFp(string(id), "115792089210356248762697446949407573530086143415290314195533631308867097853951"), nil
 	case P256K1:
-		return F.NewFp(string(id), "115792089237316195423570985008687907853269984665640564039457584007908834671663")
+		return F.NewFp(string(id), "115792089237316195423570985008687907853269984665640564039457584007908834671663"), nil
 	case P384:
-		return F.NewFp(string(id), "39402006196394479212279040100143613805079739270465446667948293404245721771496870329047266088258938001861606973112319")
+		return F.NewFp(string(id), "39402006196394479212279040100143613805079739270465446667948293404245721771496870329047266088258938001861606973112319"), nil
 	case P448:
-		return F.NewFp(string(id), "726838724295606890549323807888004534353641360687318060281490199180612328166730772686396383698676545930088884461843637361053498018365439")
+		return F.NewFp(string(id), "726838724295606890549323807888004534353641360687318060281490199180612328166730772686396383698676545930088884461843637361053498018365439"), nil
 	case P521:
-		return F.NewFp(string(id), "6864797660130609714981900799081393217269435300143305409394463459185543183397656052122559640661454554977296311391480858037121987999716643812574028291115057151")
+		return F.NewFp(string(id), "6864797660130609714981900799081393217269435300143305409394463459185543183397656052122559640661454554977296311391480858037121987999716643812574028291115057151"), nil
 	case BLS12381G1:
-		return F.NewFp(string(id), "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab")
+		return F.NewFp(string(id), "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab"), nil
 	case BLS12381G2:
-		return F.NewFp2(string(id), "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab")
+		return F.NewFp2(string(id), "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab"), nil
+	case BN254G1:
+		return F.NewFp(string(id), "21888242871839275222246405745257275088696311157297823662689037894645226208583"), nil
+	case BN254G2:
+		return F.NewFp2(string(id), "21888242871839275222246405745257275088696311157297823662689037894645226208583"), nil
+	case BLS12377G1:
+		return F.NewFp(string(id), "0x01ae3a4617c510eac63b05c06ca1493b1a22d9f300f5138f1ef3622fba094800170b5d44300000008508c00000000001"), nil
+	case BLS12377G2:
+		return newFp2(string(id), "0x01ae3a4617c510eac63b05c06ca1493b1a22d9f300f5138f1ef3622fba094800170b5d44300000008508c00000000001", -5), nil
+	case Pallas:
+		return F.NewFp(string(id), "0x40000000000000000000000000000000224698fc094cf91b992d30ed00000001"), nil
+	case Vesta:
+		return F.NewFp(string(id), "0x40000000000000000000000000000000224698fc0994a8dd8c46eb2100000001"), nil
 	default:
-		panic("field not supported")
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, id)
 	}
 }
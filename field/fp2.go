@@ -0,0 +1,165 @@
+package field
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+
+	F "github.com/armfazh/tozan-ecc/field"
+)
+
+// fp2Elt is an element a+bu of a quadratic extension.
+type fp2Elt [2]F.Elt
+
+func (e fp2Elt) String() string         { return fmt.Sprintf("\na: %v\nb: %v", e[0], e[1]) }
+func (e fp2Elt) Copy() F.Elt            { return &fp2Elt{e[0].Copy(), e[1].Copy()} }
+func (e fp2Elt) Polynomial() []*big.Int { return append(e[0].Polynomial(), e[1].Polynomial()...) }
+
+// fp2 is the quadratic extension Fp[u]/(u^2-beta) for a non-square beta of
+// Fp. It complements the extension of tozan-ecc, which only supports u^2=-1
+// and p = 3 mod 4.
+type fp2 struct {
+	base F.Field
+	name string
+	beta F.Elt
+}
+
+// newFp2 returns the extension Fp[u]/(u^2-beta) given p and beta.
+func newFp2(name string, p interface{}, beta int) F.Field {
+	base := F.NewFp(name, p)
+	b := base.Elt(beta)
+	if base.IsSquare(b) {
+		panic(fmt.Errorf("field: %v is a square, cannot extend the field", beta))
+	}
+	return fp2{base: base, name: name, beta: b}
+}
+
+func (f fp2) Elt(in interface{}) F.Elt {
+	v := reflect.ValueOf(in)
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Len() == 2 {
+		return &fp2Elt{f.base.Elt(v.Index(0).Interface()), f.base.Elt(v.Index(1).Interface())}
+	}
+	return &fp2Elt{f.base.Elt(in), f.base.Zero()}
+}
+func (f fp2) String() string {
+	return fmt.Sprintf("GF(%v) Irred: u^2-(%v)", f.name, f.beta.Polynomial()[0])
+}
+func (f fp2) P() *big.Int     { return f.base.P() }
+func (f fp2) Order() *big.Int { p := f.base.P(); return p.Mul(p, p) }
+func (f fp2) Ext() uint       { return uint(2) }
+func (f fp2) BitLen() int     { return f.base.BitLen() }
+func (f fp2) Zero() F.Elt     { return f.Elt(0) }
+func (f fp2) One() F.Elt      { return f.Elt(1) }
+func (f fp2) Generator() F.Elt {
+	return &fp2Elt{f.base.Zero(), f.base.One()}
+}
+func (f fp2) Rand(r io.Reader) F.Elt { return &fp2Elt{f.base.Rand(r), f.base.Rand(r)} }
+
+// IsEqual returns true if ff is an extension of the same field with the same
+// irreducible polynomial. Unlike the fields of tozan-ecc, it does not panic
+// when ff is of a different kind.
+func (f fp2) IsEqual(ff F.Field) bool {
+	g, ok := ff.(fp2)
+	return ok && f.P().Cmp(g.P()) == 0 && f.base.AreEqual(f.beta, g.beta)
+}
+func (f fp2) AreEqual(x, y F.Elt) bool { return f.IsZero(f.Sub(x, y)) }
+func (f fp2) IsZero(x F.Elt) bool {
+	e := x.(*fp2Elt)
+	return f.base.IsZero(e[0]) && f.base.IsZero(e[1])
+}
+
+// norm returns a^2-beta*b^2, the norm of a+bu over Fp.
+func (f fp2) norm(e *fp2Elt) F.Elt {
+	return f.base.Sub(f.base.Sqr(e[0]), f.base.Mul(f.beta, f.base.Sqr(e[1])))
+}
+
+// IsSquare returns true if x is a square, that is, if its norm is a square
+// in Fp.
+func (f fp2) IsSquare(x F.Elt) bool {
+	n := f.norm(x.(*fp2Elt))
+	return f.base.IsZero(n) || f.base.IsSquare(n)
+}
+func (f fp2) Neg(x F.Elt) F.Elt {
+	e := x.(*fp2Elt)
+	return &fp2Elt{f.base.Neg(e[0]), f.base.Neg(e[1])}
+}
+func (f fp2) Add(x, y F.Elt) F.Elt {
+	a, b := x.(*fp2Elt), y.(*fp2Elt)
+	return &fp2Elt{f.base.Add(a[0], b[0]), f.base.Add(a[1], b[1])}
+}
+func (f fp2) Sub(x, y F.Elt) F.Elt {
+	a, b := x.(*fp2Elt), y.(*fp2Elt)
+	return &fp2Elt{f.base.Sub(a[0], b[0]), f.base.Sub(a[1], b[1])}
+}
+func (f fp2) Mul(x, y F.Elt) F.Elt {
+	a, b := x.(*fp2Elt), y.(*fp2Elt)
+	a0b0 := f.base.Mul(a[0], b[0])
+	a1b1 := f.base.Mul(a[1], b[1])
+	z0 := f.base.Add(a0b0, f.base.Mul(f.beta, a1b1))
+	z1 := f.base.Add(f.base.Mul(a[0], b[1]), f.base.Mul(a[1], b[0]))
+	return &fp2Elt{z0, z1}
+}
+func (f fp2) Sqr(x F.Elt) F.Elt { return f.Mul(x, x) }
+
+// Inv returns (a-bu)/(a^2-beta*b^2).
+func (f fp2) Inv(x F.Elt) F.Elt {
+	e := x.(*fp2Elt)
+	t := f.base.Inv(f.norm(e))
+	return &fp2Elt{f.base.Mul(e[0], t), f.base.Neg(f.base.Mul(e[1], t))}
+}
+func (f fp2) Inv0(x F.Elt) F.Elt {
+	if f.IsZero(x) {
+		return f.Zero()
+	}
+	return f.Inv(x)
+}
+func (f fp2) Exp(x F.Elt, n *big.Int) F.Elt {
+	z := f.One()
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		z = f.Sqr(z)
+		if n.Bit(i) == 1 {
+			z = f.Mul(z, x)
+		}
+	}
+	return z
+}
+func (f fp2) CMov(x, y F.Elt, b bool) F.Elt {
+	a, c := x.(*fp2Elt), y.(*fp2Elt)
+	return &fp2Elt{f.base.CMov(a[0], c[0], b), f.base.CMov(a[1], c[1], b)}
+}
+
+// Sgn0 is the sgn0 function of Section 4.1 of RFC 9380 for m=2.
+func (f fp2) Sgn0(x F.Elt) int {
+	e := x.(*fp2Elt)
+	s0, s1 := f.base.Sgn0(e[0]), f.base.Sgn0(e[1])
+	z0 := 0
+	if f.base.IsZero(e[0]) {
+		z0 = 1
+	}
+	return s0 | (z0 & s1)
+}
+
+// Sqrt returns a square root of a+bu, provided that it is a square, using
+// the complex method, which only needs square roots in Fp: if b=0 the root
+// is either sqrt(a) or sqrt(a/beta)*u; otherwise the root is x0+x1u with
+// x0^2 = (a+-sqrt(a^2-beta*b^2))/2 and x1 = b/(2*x0).
+func (f fp2) Sqrt(x F.Elt) F.Elt {
+	e := x.(*fp2Elt)
+	Fp := f.base
+	if Fp.IsZero(e[1]) {
+		if Fp.IsSquare(e[0]) || Fp.IsZero(e[0]) {
+			return &fp2Elt{Fp.Sqrt(e[0]), Fp.Zero()}
+		}
+		return &fp2Elt{Fp.Zero(), Fp.Sqrt(Fp.Mul(e[0], Fp.Inv(f.beta)))}
+	}
+	alpha := Fp.Sqrt(f.norm(e))
+	half := Fp.Inv(Fp.Elt(2))
+	delta := Fp.Mul(Fp.Add(e[0], alpha), half)
+	if !Fp.IsSquare(delta) {
+		delta = Fp.Mul(Fp.Sub(e[0], alpha), half)
+	}
+	x0 := Fp.Sqrt(delta)
+	x1 := Fp.Mul(e[1], Fp.Inv(Fp.Add(x0, x0)))
+	return &fp2Elt{x0, x1}
+}
@@ -2,8 +2,11 @@ package h2c
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 
+	"github.com/armfazh/h2c-go-ref/curve"
+	"github.com/armfazh/h2c-go-ref/internal/quotient"
 	M "github.com/armfazh/h2c-go-ref/mapping"
 	C "github.com/armfazh/tozan-ecc/curve"
 	"github.com/armfazh/tozan-ecc/field"
@@ -15,55 +18,162 @@ type HashToPoint interface {
 	// IsRandomOracle returns true if the output distribution is
 	// indifferentiable from a random oracle.
 	IsRandomOracle() bool
-	// Hash returns a point on an elliptic curve given a byte string.
+	// Hash returns a point on an elliptic curve given a byte string. It panics
+	// if the expander returns an error, e.g. ErrLengthTooLarge or an error of
+	// an OTHER expander; use HashWithTrace or NewHasher to handle it instead.
 	Hash(in []byte) C.Point
+	// HashWithTrace is the same as Hash but it also returns the intermediate
+	// values computed while hashing, or the error returned by the expander.
+	HashWithTrace(in []byte) (*Trace, error)
 	// GetCurve returns the destination elliptic curve.
 	GetCurve() C.EllCurve
-	// GetHashToScalar returns a hash function that hashes strings to field elements.
+	// GetHashToScalar returns a hash function that hashes strings to field
+	// elements. It shares the domain separation tag and L of the suite, except
+	// for maps to prime-order groups where L is derived from the order; use
+	// SuiteID.GetHashToScalar to obtain a domain-separated one.
 	GetHashToScalar() HashToScalar
+	// NewHasher returns a Hasher that absorbs the input incrementally and
+	// produces the same point as Hash.
+	NewHasher() Hasher
+}
+
+// Trace holds the intermediate values computed while hashing a string to a
+// point, so they can be compared against test vectors.
+type Trace struct {
+	U []GF.Elt  // U are the field elements returned by hash_to_field.
+	Q []C.Point // Q are the points mapped from each element of U.
+	R C.Point   // R is the sum of the points in Q before clearing the cofactor.
+	P C.Point   // P is the output point.
 }
 
 // HashToScalar allows to hash string into the field of scalars used for scalar multiplication.
 type HashToScalar interface {
 	// GetScalarField returns the field of scalars.
 	GetScalarField() GF.Field
-	// Hash returns an element of a field given a byte string.
+	// Hash returns an element of a field given a byte string. Like
+	// HashToPoint.Hash, it panics if the expander returns an error; use
+	// NewHasher to handle it instead.
 	Hash(in []byte) GF.Elt
+	// NewHasher returns a ScalarHasher that absorbs the input incrementally
+	// and produces the same element as Hash.
+	NewHasher() ScalarHasher
 }
 
 type fieldEncoding struct {
-	F   GF.Field
-	Exp Expander
-	L   uint
+	F      GF.Field
+	Exp    Expander
+	L      uint
+	Decode func([]byte) GF.Elt // Decode, if set, converts L bytes into a field element.
 }
 
 func (f *fieldEncoding) GetScalarField() GF.Field { return f.F }
+func (f *fieldEncoding) NewHasher() ScalarHasher {
+	return &scalarHasher{f.Exp.NewHasher(), f}
+}
 
 // Hash deterministically hashes a string msg of any length into
 // an element of the given finite field.
-func (f *fieldEncoding) Hash(msg []byte) GF.Elt { return f.hashToField(msg, 1)[0] }
+func (f *fieldEncoding) Hash(msg []byte) GF.Elt {
+	u, err := f.hashToField(msg, 1)
+	if err != nil {
+		panic(err)
+	}
+	return u[0]
+}
 
 // hashToField is a function that hashes a string msg of any length into an
 // element of a finite field.
 func (f *fieldEncoding) hashToField(
 	msg []byte, // msg is the message to hash.
 	count uint, // count is 1 or 2 (the length of the result array).
-) []GF.Elt {
-	m := f.F.Ext()
-	length := count * m * f.L
+) ([]GF.Elt, error) {
+	if f.Decode == nil {
+		return hashToField(f.F, f.Exp, f.L, msg, count)
+	}
+	pseudo, err := f.Exp.ExpandMessage(msg, count*f.L)
+	if err != nil {
+		return nil, err
+	}
+	return f.toField(pseudo, count), nil
+}
+
+// toField converts the output of the expander into count field elements.
+func (f *fieldEncoding) toField(pseudo []byte, count uint) []GF.Elt {
+	if f.Decode == nil {
+		return bytesToField(f.F, f.L, pseudo, count)
+	}
+	u := make([]GF.Elt, count)
+	for i := range u {
+		u[i] = f.Decode(pseudo[uint(i)*f.L : uint(i+1)*f.L])
+	}
+	return u
+}
+
+// HashToField hashes strings to elements of a finite field as described in
+// Section 5.2 of RFC 9380. The field can be any field, including extension
+// fields, and need not be related to any curve.
+type HashToField struct {
+	f   GF.Field
+	exp Expander
+	l   uint
+}
+
+// NewHashToField returns a HashToField that uses L bytes of the output of the
+// expander per coefficient of each field element.
+func NewHashToField(f GF.Field, exp Expander, L uint) (*HashToField, error) {
+	if L == 0 {
+		return nil, fmt.Errorf("%w: L must be positive", ErrInvalidL)
+	}
+	return &HashToField{f, exp, L}, nil
+}
+
+// NewHashToFieldK is the same as NewHashToField but L is derived from the
+// characteristic of the field and the security level k in bits.
+func NewHashToFieldK(f GF.Field, exp Expander, k uint) (*HashToField, error) {
+	if k == 0 {
+		return nil, ErrInvalidSecurityLevel
+	}
+	return NewHashToField(f, exp, lengthL(f.P(), k))
+}
 
-	pseudo := f.Exp.Expand(msg, length)
+// Field returns the field of the output elements.
+func (h *HashToField) Field() GF.Field { return h.f }
+
+// L returns the number of bytes used per coefficient of a field element.
+func (h *HashToField) L() uint { return h.l }
+
+// Hash returns count field elements obtained from msg. It returns an error if
+// count*m*L is larger than the 65535 bytes an expander can produce, where m is
+// the extension degree of the field.
+func (h *HashToField) Hash(msg []byte, count uint) ([]GF.Elt, error) {
+	if n := uint64(count) * uint64(h.f.Ext()) * uint64(h.l); n > math.MaxUint16 {
+		return nil, fmt.Errorf("%w: %v bytes", ErrTooManyBytes, n)
+	}
+	return hashToField(h.f, h.exp, h.l, msg, count)
+}
+
+func hashToField(F GF.Field, exp Expander, L uint, msg []byte, count uint) ([]GF.Elt, error) {
+	pseudo, err := exp.ExpandMessage(msg, count*F.Ext()*L)
+	if err != nil {
+		return nil, err
+	}
+	return bytesToField(F, L, pseudo, count), nil
+}
+
+// bytesToField converts the output of an expander into count field elements.
+func bytesToField(F GF.Field, L uint, pseudo []byte, count uint) []GF.Elt {
+	m := F.Ext()
 	u := make([]GF.Elt, count)
 	v := make([]interface{}, m)
-	p := f.F.P()
+	p := F.P()
 	for i := uint(0); i < count; i++ {
 		for j := uint(0); j < m; j++ {
-			offset := f.L * (j + i*m)
-			t := pseudo[offset : offset+f.L]
+			offset := L * (j + i*m)
+			t := pseudo[offset : offset+L]
 			vj := new(big.Int).SetBytes(t)
 			v[j] = vj.Mod(vj, p)
 		}
-		u[i] = f.F.Elt(v)
+		u[i] = F.Elt(v)
 	}
 	return u
 }
@@ -72,36 +182,91 @@ type encoding struct {
 	E       C.EllCurve
 	Mapping M.MapToCurve
 	Field   *fieldEncoding
+	Group   bool // Group is set for maps to prime-order groups, see M.GroupMap.
+	ScalarL uint // ScalarL is the L used by GetHashToScalar, unless Group is set.
+	Clear   curve.CofactorClearing
 }
 
 func (e *encoding) GetCurve() C.EllCurve { return e.E }
 
+// clearCofactor maps a point to the subgroup of prime order.
+func (e *encoding) clearCofactor(P C.Point) C.Point {
+	switch {
+	case e.Group:
+		return P
+	case e.Clear != nil:
+		return e.Clear.Clear(P)
+	default:
+		return e.E.ClearCofactor(P)
+	}
+}
+
 type encodeToCurve struct{ *encoding }
 
 func (e *encoding) GetHashToScalar() HashToScalar {
+	return newScalarEncoding(e.E.Order(), e.Field.Exp, e.ScalarL, e.Group)
+}
+
+// groupScalarLen is the number of uniform bytes that HashToScalar reduces
+// modulo the order of ristretto255 and decaf448, see Section 4 of RFC 9497.
+const groupScalarLen = 64
+
+// newScalarEncoding returns a fieldEncoding onto the integers modulo order.
+// For prime-order groups, L is ignored and the scalars are obtained as in
+// RFC 9497, that is, groupScalarLen bytes are interpreted in little-endian
+// order and reduced modulo order.
+func newScalarEncoding(order *big.Int, exp Expander, L uint, group bool) *fieldEncoding {
+	S := field.NewFp(fmt.Sprintf("%v", order), order)
+	if !group {
+		return &fieldEncoding{F: S, Exp: exp, L: L}
+	}
 	return &fieldEncoding{
-		F:   field.NewFp(fmt.Sprintf("%v", e.E.Order()), e.E.Order()),
-		Exp: e.Field.Exp,
-		L:   e.Field.L,
+		F:      S,
+		Exp:    exp,
+		L:      groupScalarLen,
+		Decode: func(b []byte) GF.Elt { return S.Elt(quotient.BytesToInt(b)) },
 	}
 }
 
-func (s *encodeToCurve) IsRandomOracle() bool { return false }
-func (s *encodeToCurve) Hash(in []byte) C.Point {
-	u := s.Field.hashToField(in, 1)
+func (s *encodeToCurve) IsRandomOracle() bool   { return false }
+func (s *encodeToCurve) Hash(in []byte) C.Point { return mustTrace(s.HashWithTrace(in)).P }
+func (s *encodeToCurve) HashWithTrace(in []byte) (*Trace, error) {
+	u, err := s.Field.hashToField(in, 1)
+	if err != nil {
+		return nil, err
+	}
+	return s.trace(u), nil
+}
+func (s *encodeToCurve) NewHasher() Hasher { return newHasher(s.Field, 1, s.trace) }
+func (s *encodeToCurve) trace(u []GF.Elt) *Trace {
 	Q := s.Mapping.Map(u[0])
-	P := s.E.ClearCofactor(Q)
-	return P
+	P := s.clearCofactor(Q)
+	return &Trace{U: u, Q: []C.Point{Q}, R: Q, P: P}
 }
 
 type hashToCurve struct{ *encoding }
 
-func (s *hashToCurve) IsRandomOracle() bool { return true }
-func (s *hashToCurve) Hash(in []byte) C.Point {
-	u := s.Field.hashToField(in, 2)
+func (s *hashToCurve) IsRandomOracle() bool   { return true }
+func (s *hashToCurve) Hash(in []byte) C.Point { return mustTrace(s.HashWithTrace(in)).P }
+func (s *hashToCurve) HashWithTrace(in []byte) (*Trace, error) {
+	u, err := s.Field.hashToField(in, 2)
+	if err != nil {
+		return nil, err
+	}
+	return s.trace(u), nil
+}
+func (s *hashToCurve) NewHasher() Hasher { return newHasher(s.Field, 2, s.trace) }
+func (s *hashToCurve) trace(u []GF.Elt) *Trace {
 	Q0 := s.Mapping.Map(u[0])
 	Q1 := s.Mapping.Map(u[1])
 	R := s.E.Add(Q0, Q1)
-	P := s.E.ClearCofactor(R)
-	return P
+	P := s.clearCofactor(R)
+	return &Trace{U: u, Q: []C.Point{Q0, Q1}, R: R, P: P}
+}
+
+func mustTrace(t *Trace, err error) *Trace {
+	if err != nil {
+		panic(err)
+	}
+	return t
 }
@@ -0,0 +1,68 @@
+package h2c
+
+import (
+	"io"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Hasher hashes a message written in pieces to a point. Calling Sum does not
+// change the state of the Hasher, so more data can be written afterwards.
+type Hasher interface {
+	// Write absorbs more data into the state; it never returns an error.
+	io.Writer
+	// Reset discards the data written so far.
+	Reset()
+	// Sum returns the point obtained from the data written so far, or the
+	// error returned by the expander.
+	Sum() (C.Point, error)
+}
+
+// ScalarHasher is the same as Hasher but it hashes to a field element.
+type ScalarHasher interface {
+	io.Writer
+	Reset()
+	Sum() (GF.Elt, error)
+}
+
+// ExpandHasher is the same as Hasher but it expands the message to a byte
+// string of length n. Like Expander.ExpandMessage, Sum returns an error if n
+// is too large.
+type ExpandHasher interface {
+	io.Writer
+	Reset()
+	Sum(n uint) ([]byte, error)
+}
+
+type pointHasher struct {
+	ExpandHasher
+	f     *fieldEncoding
+	count uint
+	trace func([]GF.Elt) *Trace
+}
+
+func newHasher(f *fieldEncoding, count uint, trace func([]GF.Elt) *Trace) Hasher {
+	return &pointHasher{f.Exp.NewHasher(), f, count, trace}
+}
+
+func (h *pointHasher) Sum() (C.Point, error) {
+	pseudo, err := h.ExpandHasher.Sum(h.count * h.f.F.Ext() * h.f.L)
+	if err != nil {
+		return nil, err
+	}
+	return h.trace(h.f.toField(pseudo, h.count)).P, nil
+}
+
+type scalarHasher struct {
+	ExpandHasher
+	f *fieldEncoding
+}
+
+func (h *scalarHasher) Sum() (GF.Elt, error) {
+	pseudo, err := h.ExpandHasher.Sum(h.f.F.Ext() * h.f.L)
+	if err != nil {
+		return nil, err
+	}
+	return h.f.toField(pseudo, 1)[0], nil
+}
@@ -0,0 +1,65 @@
+package h2c_test
+
+import (
+	"testing"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+	C "github.com/armfazh/tozan-ecc/curve"
+)
+
+func TestHasher(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-hasher")
+	msg := []byte("a512_" + string(make([]byte, 512)))
+	for _, id := range registeredSuites {
+		hashToCurve, err := id.Get(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := hashToCurve.Hash(msg)
+		h := hashToCurve.NewHasher()
+		_, _ = h.Write([]byte("discarded"))
+		h.Reset()
+		for i := 0; i < len(msg); i += 100 {
+			end := i + 100
+			if end > len(msg) {
+				end = len(msg)
+			}
+			_, _ = h.Write(msg[i:end])
+		}
+		if got := sum(t, h); !got.IsEqual(want) {
+			t.Fatalf("suite: %v\ngot:  %v\nwant: %v", id, got, want)
+		}
+		if got := sum(t, h); !got.IsEqual(want) {
+			t.Fatalf("suite: %v second Sum\ngot:  %v\nwant: %v", id, got, want)
+		}
+		_, _ = h.Write([]byte("abc"))
+		if got, want := sum(t, h), hashToCurve.Hash(append(msg, "abc"...)); !got.IsEqual(want) {
+			t.Fatalf("suite: %v Write after Sum\ngot:  %v\nwant: %v", id, got, want)
+		}
+
+		hashToScalar, err := id.GetHashToScalar(dst, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		S := hashToScalar.GetScalarField()
+		hs := hashToScalar.NewHasher()
+		_, _ = hs.Write(msg[:7])
+		_, _ = hs.Write(msg[7:])
+		got, err := hs.Sum()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := hashToScalar.Hash(msg); !S.AreEqual(got, want) {
+			t.Fatalf("suite: %v scalar\ngot:  %v\nwant: %v", id, got, want)
+		}
+	}
+}
+
+func sum(t *testing.T, h h2c.Hasher) C.Point {
+	t.Helper()
+	P, err := h.Sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return P
+}
@@ -0,0 +1,69 @@
+package h2c_test
+
+import (
+	"crypto"
+	"errors"
+	"testing"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+	"github.com/armfazh/h2c-go-ref/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+func TestHashToField(t *testing.T) {
+	id := h2c.BLS12381G2_XMDSHA256_SSWU_RO_
+	dst := []byte("QUUX-V01-CS02-with-" + id)
+	msg := []byte("abc")
+	hashToCurve, err := id.Get(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp, err := h2c.ExpanderDesc{Type: h2c.XMD, ID: uint(crypto.SHA256)}.Get(dst, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	E, err := curve.BLS12381G2.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	F := E.Field()
+	h, err := h2c.NewHashToFieldK(F, exp, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.L() != 64 {
+		t.Fatalf("got: %v want: %v", h.L(), 64)
+	}
+	u, err := h.Hash(msg, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trace, err := hashToCurve.HashWithTrace(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := trace.U
+	for i := range want {
+		if !F.AreEqual(u[i], want[i]) {
+			t.Fatalf("got:  %v\nwant: %v", u[i], want[i])
+		}
+	}
+
+	Fq := GF.NewFp("13", 13)
+	h, err = h2c.NewHashToField(Fq, exp, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u, err = h.Hash(msg, 7); err != nil || len(u) != 7 {
+		t.Fatalf("got: %v, %v", len(u), err)
+	}
+	if _, err = h.Hash(msg, 65535/5+1); !errors.Is(err, h2c.ErrTooManyBytes) {
+		t.Fatalf("got:  %v\nwant: %v", err, h2c.ErrTooManyBytes)
+	}
+	if _, err = h2c.NewHashToField(Fq, exp, 0); !errors.Is(err, h2c.ErrInvalidL) {
+		t.Fatalf("got:  %v\nwant: %v", err, h2c.ErrInvalidL)
+	}
+	if _, err = h2c.NewHashToFieldK(Fq, exp, 0); !errors.Is(err, h2c.ErrInvalidSecurityLevel) {
+		t.Fatalf("got:  %v\nwant: %v", err, h2c.ErrInvalidSecurityLevel)
+	}
+}
@@ -0,0 +1,22 @@
+// Package hexfmt formats field elements as in the test vectors of RFC 9380.
+package hexfmt
+
+import (
+	"fmt"
+	"strings"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// EltToHex encodes each coefficient of a field element as a hexadecimal
+// string padded to the length of the field modulus; coefficients are
+// separated by commas.
+func EltToHex(F GF.Field, e GF.Elt) string {
+	size := 2 * ((F.P().BitLen() + 7) / 8)
+	poly := e.Polynomial()
+	s := make([]string, len(poly))
+	for i := range poly {
+		s[i] = fmt.Sprintf("0x%0*x", size, poly[i])
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,42 @@
+// Package quotient has the helpers shared by ristretto255 and decaf448, the
+// prime-order groups of RFC 9496 that are quotients of Edwards curves. Both
+// encode elements as non-negative field elements in little-endian order.
+package quotient
+
+import (
+	"math/big"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// IsNegative is IS_NEGATIVE of Section 4.1 of RFC 9496, that is, it returns
+// true if the least significant bit of e is set.
+func IsNegative(F GF.Field, e GF.Elt) bool { return F.Sgn0(e) == 1 }
+
+// Abs is CT_ABS of Section 4.1 of RFC 9496; it returns e or -e, whichever
+// is non-negative.
+func Abs(F GF.Field, e GF.Elt) GF.Elt {
+	if IsNegative(F, e) {
+		return F.Neg(e)
+	}
+	return e
+}
+
+// BytesToInt interprets b as an integer in little-endian order.
+func BytesToInt(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+// FieldToBytes encodes a field element in n bytes in little-endian order.
+func FieldToBytes(e GF.Elt, n int) []byte {
+	be := e.Polynomial()[0].Bytes()
+	b := make([]byte, n)
+	for i := range be {
+		b[i] = be[len(be)-1-i]
+	}
+	return b
+}
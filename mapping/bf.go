@@ -17,21 +17,32 @@ type bf struct {
 
 func (m bf) String() string { return fmt.Sprintf("Boneh-Franklin for E: %v", m.E) }
 
-// NewBF implements the Boneh-Franklin method.
-func NewBF(e C.EllCurve) MapToCurve {
-	if s := (&bf{E: e.(C.W)}); s.verify() {
-		s.precmp()
-		return s
+// NewBF implements the Boneh-Franklin method. It returns an error if the curve
+// fails the preconditions of the method.
+func NewBF(e C.EllCurve) (MapToCurve, error) {
+	curve, ok := e.(C.W)
+	if !ok {
+		return nil, &Error{BF, ErrCurveModel}
 	}
-	panic(fmt.Errorf("Failed restrictions for ell2"))
+	s := &bf{E: curve}
+	if err := s.verify(); err != nil {
+		return nil, &Error{BF, err}
+	}
+	s.precmp()
+	return s, nil
 }
-func (m *bf) verify() bool {
+func (m *bf) verify() error {
 	F := m.E.F
 	q := F.Order()
-	cond1 := q.Mod(q, big.NewInt(3)).Int64() == int64(2)
-	cond2 := F.IsZero(m.E.A)
-	cond3 := !F.IsZero(m.E.B)
-	return cond1 && cond2 && cond3
+	switch {
+	case q.Mod(q, big.NewInt(3)).Int64() != int64(2): // q == 2 (mod 3)
+		return ErrQNot2Mod3
+	case !F.IsZero(m.E.A): // A == 0
+		return ErrANotZero
+	case F.IsZero(m.E.B): // B != 0
+		return ErrBIsZero
+	}
+	return nil
 }
 func (m *bf) precmp() {
 	q := m.E.F.Order()
@@ -0,0 +1,31 @@
+package mapping
+
+import (
+	"fmt"
+
+	"github.com/armfazh/h2c-go-ref/curve"
+	"github.com/armfazh/h2c-go-ref/decaf448"
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+type d448Map struct{ E C.T }
+
+func (m d448Map) String() string { return fmt.Sprintf("decaf448 map for E: %v", m.E) }
+
+// NewD448Map implements the one-way map to decaf448 of RFC 9496. It
+// returns an error if the curve is not edwards448.
+func NewD448Map(e C.EllCurve) (MapToCurve, error) {
+	E, ok := e.(C.T)
+	if !ok {
+		return nil, &Error{D448MAP, ErrCurveModel}
+	}
+	if curve.ID(E.Name) != curve.Edwards448 {
+		return nil, &Error{D448MAP, ErrUnsupported}
+	}
+	return &d448Map{E}, nil
+}
+
+func (m *d448Map) Map(u GF.Elt) C.Point   { return decaf448.Map(u) }
+func (m *d448Map) ElementLen() uint       { return decaf448.UniformBytesLen / 2 }
+func (m *d448Map) Decode(b []byte) GF.Elt { return decaf448.DecodeField(b) }
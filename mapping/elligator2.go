@@ -1,13 +1,12 @@
 package mapping
 
 import (
-	"fmt"
-
 	C "github.com/armfazh/tozan-ecc/curve"
 )
 
-// NewElligator2 implements the Elligator2 method.
-func NewElligator2(e C.EllCurve) MapToCurve {
+// NewElligator2 implements the Elligator2 method. It returns an error if the
+// curve fails the preconditions of the method.
+func NewElligator2(e C.EllCurve) (MapToCurve, error) {
 	switch curve := e.(type) {
 	case C.W:
 		return newWA0Ell2(curve)
@@ -18,6 +17,6 @@ func NewElligator2(e C.EllCurve) MapToCurve {
 	case C.T:
 		return newTEEll2(curve)
 	default:
-		panic(fmt.Errorf("Curve doesn't support an elligator2 mapping"))
+		return nil, &Error{ELL2, ErrCurveModel}
 	}
 }
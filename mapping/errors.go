@@ -0,0 +1,46 @@
+package mapping
+
+import (
+	"errors"
+	"fmt"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Preconditions that a curve or its parameters can fail when a mapping is
+// constructed. They are wrapped by Error and can be tested with errors.Is.
+var (
+	ErrCurveModel  = errors.New("curve model not supported")
+	ErrAIsZero     = errors.New("A == 0")
+	ErrANotZero    = errors.New("A != 0")
+	ErrBIsZero     = errors.New("B == 0")
+	ErrBNotZero    = errors.New("B != 0")
+	ErrZIsSquare   = errors.New("Z is square")
+	ErrZIsMinusOne = errors.New("Z == -1")
+	ErrGNotSquare  = errors.New("g(B/(Z*A)) not square")
+	ErrQNot2Mod3   = errors.New("q != 2 mod 3")
+	ErrQNot3Mod4   = errors.New("q != 3 mod 4")
+	ErrInvalidZ    = errors.New("invalid Z")
+	ErrIsogeny     = errors.New("isogeny not available")
+	ErrUnsupported = errors.New("mapping not supported")
+)
+
+// Error reports the precondition that failed when constructing a mapping.
+type Error struct {
+	Map ID    // Mapping being constructed.
+	Err error // Precondition that failed.
+}
+
+func (e *Error) Error() string { return fmt.Sprintf("mapping: %v: %v", e.Map, e.Err) }
+func (e *Error) Unwrap() error { return e.Err }
+
+// newElt converts v into an element of f, reporting an error instead of
+// panicking if v cannot be represented.
+func newElt(f GF.Field, v interface{}) (e GF.Elt, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, err = nil, fmt.Errorf("%w: %v", ErrInvalidZ, r)
+		}
+	}()
+	return f.Elt(v), nil
+}
@@ -15,6 +15,8 @@
 package mapping
 
 import (
+	"strconv"
+
 	C "github.com/armfazh/tozan-ecc/curve"
 	GF "github.com/armfazh/tozan-ecc/field"
 )
@@ -24,6 +26,33 @@ type MapToCurve interface {
 	Map(GF.Elt) C.Point
 }
 
+// GroupMap is implemented by mappings onto prime-order groups that are
+// quotients of a curve, such as ristretto255 and decaf448. The points returned
+// by Map are representatives of group elements, so the cofactor must not be
+// cleared, and the field elements are obtained from uniform bytes using
+// Decode.
+type GroupMap interface {
+	MapToCurve
+	// ElementLen returns the number of uniform bytes per field element.
+	ElementLen() uint
+	// Decode returns the field element obtained from ElementLen bytes.
+	Decode([]byte) GF.Elt
+}
+
+// ZParameter is implemented by mappings that depend on a constant Z.
+type ZParameter interface {
+	// GetZ returns the constant Z used by the mapping.
+	GetZ() GF.Elt
+}
+
+// getZ returns the constant Z of an inner mapping, or nil if it has none.
+func getZ(m MapToCurve) GF.Elt {
+	if z, ok := m.(ZParameter); ok {
+		return z.GetZ()
+	}
+	return nil
+}
+
 // ID is an identifier of a mapping.
 type ID uint
 
@@ -36,29 +65,63 @@ const (
 	ELL2
 	// SVDW is Shallue-van de Woestijne method.
 	SVDW
+	// R255MAP is the map to the ristretto255 group.
+	R255MAP
+	// D448MAP is the map to the decaf448 group.
+	D448MAP
 )
 
+func (id ID) String() string {
+	switch id {
+	case BF:
+		return "BF"
+	case SSWU:
+		return "SSWU"
+	case ELL2:
+		return "ELL2"
+	case SVDW:
+		return "SVDW"
+	case R255MAP:
+		return "R255MAP"
+	case D448MAP:
+		return "D448MAP"
+	default:
+		return "ID(" + strconv.Itoa(int(id)) + ")"
+	}
+}
+
+// IsGroupMap returns true if the mapping is a GroupMap.
+func (id ID) IsGroupMap() bool { return id == R255MAP || id == D448MAP }
+
 // MapDescriptor describes parameters of a mapping to curve.
 type MapDescriptor struct {
 	ID  ID
 	Z   interface{}
-	Iso func() C.Isogeny
+	Iso func() (C.Isogeny, error)
 }
 
 // Get returns a MapToCurve implementation based on ID provided. Some arguments
-// can be set to nil if there are not required by the mapping.
-func (d MapDescriptor) Get(e C.EllCurve) MapToCurve {
+// can be set to nil if there are not required by the mapping. It returns an
+// error if the curve does not satisfy the preconditions of the mapping.
+func (d MapDescriptor) Get(e C.EllCurve) (MapToCurve, error) {
 	switch d.ID {
 	case BF:
 		return NewBF(e)
 	case SSWU:
-		z := e.Field().Elt(d.Z)
+		z, err := newElt(e.Field(), d.Z)
+		if err != nil {
+			return nil, &Error{SSWU, err}
+		}
 		return NewSSWU(e, z, d.Iso)
 	case SVDW:
 		return NewSVDW(e)
 	case ELL2:
 		return NewElligator2(e)
+	case R255MAP:
+		return NewR255Map(e)
+	case D448MAP:
+		return NewD448Map(e)
 	default:
-		panic("Mapping not supported")
+		return nil, &Error{d.ID, ErrUnsupported}
 	}
 }
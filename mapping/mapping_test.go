@@ -1,6 +1,7 @@
 package mapping_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/armfazh/h2c-go-ref/mapping"
@@ -14,7 +15,10 @@ func TestBF(t *testing.T) {
 		E, _, _ := id.New()
 		F := E.Field()
 		n := F.Order().Int64()
-		m := mapping.NewBF(E)
+		m, err := mapping.NewBF(E)
+		if err != nil {
+			t.Fatal(err)
+		}
 		for i := int64(0); i < n; i++ {
 			u := F.Elt(i)
 			P := m.Map(u)
@@ -31,7 +35,10 @@ func TestEll2(t *testing.T) {
 		E, _, _ := id.New()
 		F := E.Field()
 		n := F.Order().Int64()
-		m := mapping.NewElligator2(E)
+		m, err := mapping.NewElligator2(E)
+		if err != nil {
+			t.Fatal(err)
+		}
 		for i := int64(0); i < n; i++ {
 			u := F.Elt(i)
 			P := m.Map(u)
@@ -48,7 +55,10 @@ func TestSVDW(t *testing.T) {
 		E, _, _ := id.New()
 		F := E.Field()
 		n := F.Order().Int64()
-		m := mapping.NewSVDW(E)
+		m, err := mapping.NewSVDW(E)
+		if err != nil {
+			t.Fatal(err)
+		}
 		for i := int64(0); i < n; i++ {
 			u := F.Elt(i)
 			P := m.Map(u)
@@ -72,12 +82,13 @@ func TestSSWU(t *testing.T) {
 		E, _, _ := c.id.New()
 		F := E.Field()
 		n := F.Order().Int64()
-		iso := func() C.Isogeny { return doubleIso{E} }
+		iso := func() (C.Isogeny, error) { return doubleIso{E}, nil }
 		Z := F.Elt(c.Z)
-		for _, m := range []mapping.MapToCurve{
-			mapping.NewSSWU(E, Z, nil),
-			mapping.NewSSWU(E, Z, iso),
-		} {
+		for _, isogeny := range []func() (C.Isogeny, error){nil, iso} {
+			m, err := mapping.NewSSWU(E, Z, isogeny)
+			if err != nil {
+				t.Fatal(err)
+			}
 			for i := int64(0); i < n; i++ {
 				u := F.Elt(i)
 				P := m.Map(u)
@@ -89,6 +100,34 @@ func TestSSWU(t *testing.T) {
 	}
 }
 
+func TestPreconditions(t *testing.T) {
+	var tests = []struct {
+		id  toy.ID
+		Map mapping.MapDescriptor
+		err error
+	}{
+		{toy.W0, mapping.MapDescriptor{ID: mapping.SSWU, Z: 1}, mapping.ErrZIsSquare},
+		{toy.W0, mapping.MapDescriptor{ID: mapping.SSWU, Z: -1}, mapping.ErrZIsSquare},
+		{toy.W1, mapping.MapDescriptor{ID: mapping.SSWU, Z: 2}, mapping.ErrAIsZero},
+		{toy.W0, mapping.MapDescriptor{ID: mapping.SSWU, Z: "z"}, mapping.ErrInvalidZ},
+		{toy.W0, mapping.MapDescriptor{ID: mapping.BF}, mapping.ErrANotZero},
+		{toy.W0, mapping.MapDescriptor{ID: mapping.ELL2}, mapping.ErrQNot3Mod4},
+		{toy.M0, mapping.MapDescriptor{ID: mapping.SVDW}, mapping.ErrCurveModel},
+		{toy.W0, mapping.MapDescriptor{ID: 99}, mapping.ErrUnsupported},
+	}
+	for _, v := range tests {
+		E, _, _ := v.id.New()
+		m, err := v.Map.Get(E)
+		if m != nil || !errors.Is(err, v.err) {
+			t.Fatalf("curve: %v map: %v\ngot:  %v\nwant: %v", v.id, v.Map.ID, err, v.err)
+		}
+		var e *mapping.Error
+		if !errors.As(err, &e) || e.Map != v.Map.ID {
+			t.Fatalf("curve: %v map: %v\ngot:  %v", v.id, v.Map.ID, err)
+		}
+	}
+}
+
 type doubleIso struct{ E C.EllCurve }
 
 func (d doubleIso) Domain() C.EllCurve     { return d.E }
@@ -14,10 +14,15 @@ type mtEll2 struct {
 }
 
 func (m mtEll2) String() string { return fmt.Sprintf("Montgomery Elligator2 for E: %v", m.E) }
+func (m mtEll2) GetZ() GF.Elt   { return getZ(m.MapToCurve) }
 
-func newMTEll2(e C.M) MapToCurve {
+func newMTEll2(e C.M) (MapToCurve, error) {
 	rat := e.ToWeierstrassC()
-	return &mtEll2{e, rat, newWCEll2(rat.Codomain().(C.WC))}
+	m, err := newWCEll2(rat.Codomain().(C.WC))
+	if err != nil {
+		return nil, err
+	}
+	return &mtEll2{e, rat, m}, nil
 }
 
 func (m *mtEll2) Map(u GF.Elt) C.Point { return m.Pull(m.MapToCurve.Map(u)) }
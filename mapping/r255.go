@@ -0,0 +1,31 @@
+package mapping
+
+import (
+	"fmt"
+
+	"github.com/armfazh/h2c-go-ref/curve"
+	"github.com/armfazh/h2c-go-ref/ristretto255"
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+type r255Map struct{ E C.T }
+
+func (m r255Map) String() string { return fmt.Sprintf("ristretto255 map for E: %v", m.E) }
+
+// NewR255Map implements the one-way map to ristretto255 of RFC 9496. It
+// returns an error if the curve is not edwards25519.
+func NewR255Map(e C.EllCurve) (MapToCurve, error) {
+	E, ok := e.(C.T)
+	if !ok {
+		return nil, &Error{R255MAP, ErrCurveModel}
+	}
+	if curve.ID(E.Name) != curve.Edwards25519 {
+		return nil, &Error{R255MAP, ErrUnsupported}
+	}
+	return &r255Map{E}, nil
+}
+
+func (m *r255Map) Map(u GF.Elt) C.Point   { return ristretto255.Map(u) }
+func (m *r255Map) ElementLen() uint       { return ristretto255.UniformBytesLen / 2 }
+func (m *r255Map) Decode(b []byte) GF.Elt { return ristretto255.DecodeField(b) }
@@ -9,16 +9,27 @@ import (
 
 // NewSSWU implements the Simplified SWU method. If a non-nil isogeny (e0 -> e)
 // is provided, it first maps points to e0 and then applies the isogeny to get
-// a point on e.
-func NewSSWU(e C.EllCurve, z GF.Elt, iso func() C.Isogeny) MapToCurve {
-	E := e.(C.W)
+// a point on e. It returns an error if either e or e0 fail the preconditions
+// of the method.
+func NewSSWU(e C.EllCurve, z GF.Elt, iso func() (C.Isogeny, error)) (MapToCurve, error) {
+	E, ok := e.(C.W)
+	if !ok {
+		return nil, &Error{SSWU, ErrCurveModel}
+	}
 	F := E.F
 	cond1 := F.IsZero(E.A)
 	cond2 := F.IsZero(E.B)
 	cond3 := iso != nil
 	if (cond1 || cond2) && cond3 {
-		isogeny := iso()
-		return &sswuAB0{E, isogeny, newSSWU(isogeny.Domain(), z)}
+		isogeny, err := iso()
+		if err != nil {
+			return nil, &Error{SSWU, fmt.Errorf("%w: %v", ErrIsogeny, err)}
+		}
+		m, err := newSSWU(isogeny.Domain(), z)
+		if err != nil {
+			return nil, err
+		}
+		return &sswuAB0{E, isogeny, m}, nil
 	}
 	return newSSWU(e, z)
 }
@@ -30,14 +41,19 @@ type sswu struct {
 }
 
 func (m sswu) String() string { return fmt.Sprintf("Simple SWU for E: %v", m.E) }
+func (m sswu) GetZ() GF.Elt   { return m.Z }
 
-func newSSWU(e C.EllCurve, z GF.Elt) MapToCurve {
-	curve := e.(C.W)
-	if s := (&sswu{E: curve, Z: z}); s.verify() {
-		s.precmp()
-		return s
+func newSSWU(e C.EllCurve, z GF.Elt) (MapToCurve, error) {
+	curve, ok := e.(C.W)
+	if !ok {
+		return nil, &Error{SSWU, ErrCurveModel}
+	}
+	s := &sswu{E: curve, Z: z}
+	if err := s.verify(); err != nil {
+		return nil, &Error{SSWU, err}
 	}
-	panic(fmt.Errorf("Failed restrictions for sswu"))
+	s.precmp()
+	return s, nil
 }
 
 func (m *sswu) precmp() {
@@ -50,18 +66,26 @@ func (m *sswu) precmp() {
 	m.c2 = F.Neg(t0)      // -1/Z
 }
 
-func (m *sswu) verify() bool {
+func (m *sswu) verify() error {
 	F := m.E.F
-	precond1 := !F.IsZero(m.E.A)         // A != 0
-	precond2 := !F.IsZero(m.E.B)         // B != 0
-	cond1 := !F.IsSquare(m.Z)            // Z is non-square
-	cond2 := !F.AreEqual(m.Z, F.Elt(-1)) // Z != -1
-	t0 := F.Mul(m.Z, m.E.A)              // Z*A
-	t0 = F.Inv(t0)                       // 1/(Z*A)
-	t0 = F.Mul(t0, m.E.B)                // B/(Z*A)
-	g := m.E.EvalRHS(t0)                 // g(B/(Z*A))
-	cond4 := F.IsSquare(g)               // g(B/(Z*A)) is square
-	return precond1 && precond2 && cond1 && cond2 && cond4
+	switch {
+	case F.IsZero(m.E.A): // A != 0
+		return ErrAIsZero
+	case F.IsZero(m.E.B): // B != 0
+		return ErrBIsZero
+	case F.IsSquare(m.Z): // Z is non-square
+		return ErrZIsSquare
+	case F.AreEqual(m.Z, F.Elt(-1)): // Z != -1
+		return ErrZIsMinusOne
+	}
+	t0 := F.Mul(m.Z, m.E.A) // Z*A
+	t0 = F.Inv(t0)          // 1/(Z*A)
+	t0 = F.Mul(t0, m.E.B)   // B/(Z*A)
+	g := m.E.EvalRHS(t0)    // g(B/(Z*A))
+	if !F.IsSquare(g) {     // g(B/(Z*A)) is square
+		return ErrGNotSquare
+	}
+	return nil
 }
 
 func (m *sswu) sqrtRatio(u GF.Elt, v GF.Elt) (bool, GF.Elt) {
@@ -116,5 +140,6 @@ type sswuAB0 struct {
 }
 
 func (m sswuAB0) String() string { return fmt.Sprintf("Simple SWU AB==0 for E: %v", m.E) }
+func (m sswuAB0) GetZ() GF.Elt   { return getZ(m.MapToCurve) }
 
 func (m *sswuAB0) Map(u GF.Elt) C.Point { return m.iso.Push(m.MapToCurve.Map(u)) }
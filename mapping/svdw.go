@@ -14,13 +14,18 @@ type svdw struct {
 }
 
 func (m svdw) String() string { return fmt.Sprintf("SVDW for E: %v", m.E) }
+func (m svdw) GetZ() GF.Elt   { return m.Z }
 
-// NewSVDW implements the Shallue-van de Woestijne method.
-func NewSVDW(e C.EllCurve) MapToCurve {
-	curve := e.(C.W)
+// NewSVDW implements the Shallue-van de Woestijne method. It returns an error
+// if the curve is not in Weierstrass form.
+func NewSVDW(e C.EllCurve) (MapToCurve, error) {
+	curve, ok := e.(C.W)
+	if !ok {
+		return nil, &Error{SVDW, ErrCurveModel}
+	}
 	s := &svdw{E: curve}
 	s.precmp()
-	return s
+	return s, nil
 }
 
 func (m *svdw) findZ() {
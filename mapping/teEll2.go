@@ -15,22 +15,29 @@ type teEll2 struct {
 }
 
 func (m teEll2) String() string { return fmt.Sprintf("Edwards Elligator2 for E: %v", m.E) }
+func (m teEll2) GetZ() GF.Elt   { return getZ(m.MapToCurve) }
 
-func newTEEll2(e C.T) MapToCurve {
+func newTEEll2(e C.T) (MapToCurve, error) {
 	var rat C.RationalMap
 	var ell2Map MapToCurve
+	var err error
 	switch curve.ID(e.Name) {
 	case curve.Edwards25519:
-		rat = curve.FromTe2Mt25519()
-		ell2Map = newMTEll2(rat.Codomain().(C.M))
+		if rat, err = curve.FromTe2Mt25519(); err == nil {
+			ell2Map, err = newMTEll2(rat.Codomain().(C.M))
+		}
 	case curve.Edwards448:
-		rat = curve.FromTe2Mt4ISO448()
-		ell2Map = newMTEll2(rat.Codomain().(C.M))
+		if rat, err = curve.FromTe2Mt4ISO448(); err == nil {
+			ell2Map, err = newMTEll2(rat.Codomain().(C.M))
+		}
 	default:
 		rat = e.ToWeierstrassC()
-		ell2Map = newWCEll2(rat.Codomain().(C.WC))
+		ell2Map, err = newWCEll2(rat.Codomain().(C.WC))
 	}
-	return &teEll2{e, rat, ell2Map}
+	if err != nil {
+		return nil, err
+	}
+	return &teEll2{e, rat, ell2Map}, nil
 }
 
 func (m *teEll2) Map(u GF.Elt) C.Point { return m.Pull(m.MapToCurve.Map(u)) }
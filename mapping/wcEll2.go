@@ -13,13 +13,17 @@ type wcEll2 struct {
 }
 
 func (m wcEll2) String() string { return fmt.Sprintf("Elligator2 for E: %v", m.E) }
+func (m wcEll2) GetZ() GF.Elt   { return m.Z }
 
-func newWCEll2(e C.WC) MapToCurve {
+func newWCEll2(e C.WC) (MapToCurve, error) {
 	F := e.F
-	if !F.IsZero(e.A) && !F.IsZero(e.B) { // A != 0 and  B != 0
-		return &wcEll2{e, findZ(F)}
+	switch {
+	case F.IsZero(e.A): // A != 0
+		return nil, &Error{ELL2, ErrAIsZero}
+	case F.IsZero(e.B): // B != 0
+		return nil, &Error{ELL2, ErrBIsZero}
 	}
-	panic("Curve didn't match elligator2 mapping")
+	return &wcEll2{e, findZ(F)}, nil
 }
 
 func findZ(f GF.Field) GF.Elt {
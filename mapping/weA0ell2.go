@@ -14,17 +14,18 @@ type wA0ell2 struct {
 
 func (m wA0ell2) String() string { return fmt.Sprintf("Elligator2A0 for E: %v", m.E) }
 
-func newWA0Ell2(e C.W) MapToCurve {
+func newWA0Ell2(e C.W) (MapToCurve, error) {
 	F := e.F
 	q := F.Order()
-	precond1 := q.Mod(q, big.NewInt(4)).Int64() == int64(3) // q == 3 (mod 4)
-	precond2 := !F.IsZero(e.A)                              // A != 0
-	precond3 := F.IsZero(e.B)                               // B == 0
-
-	if precond1 && precond2 && precond3 {
-		return &wA0ell2{e}
+	switch {
+	case q.Mod(q, big.NewInt(4)).Int64() != int64(3): // q == 3 (mod 4)
+		return nil, &Error{ELL2, ErrQNot3Mod4}
+	case F.IsZero(e.A): // A != 0
+		return nil, &Error{ELL2, ErrAIsZero}
+	case !F.IsZero(e.B): // B == 0
+		return nil, &Error{ELL2, ErrBNotZero}
 	}
-	panic("Curve didn't match elligator2 mapping")
+	return &wA0ell2{e}, nil
 }
 
 func (m *wA0ell2) Map(u GF.Elt) C.Point {
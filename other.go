@@ -0,0 +1,94 @@
+package h2c
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrExpanderRegistered is returned when registering an expander whose id or
+// HASH_ID is already in use.
+var ErrExpanderRegistered = errors.New("expander already registered")
+
+// ExpanderFunc returns an Expander that uses dst as domain separation tag and
+// targets a security level of k bits.
+type ExpanderFunc func(dst []byte, k uint) (Expander, error)
+
+type registeredExpander struct {
+	hashID string
+	new    ExpanderFunc
+}
+
+var (
+	expandersMu    sync.RWMutex
+	otherExpanders = map[uint]registeredExpander{
+		XMDBLAKE2bZcash: {"XMD:BLAKE2b", newExpanderZcash},
+	}
+)
+
+// RegisterExpander registers a user-designed expander, so ExpanderDesc{OTHER, id}
+// resolves to the Expander returned by f. The hashID is used in suite
+// identifiers, e.g. "HKDF:SHA-256", or "XMD:KECCAK-256" for an expander built
+// with NewExpanderXMD; hence, it must not contain underscores, and it must not
+// be the HASH_ID of a built-in expander.
+func RegisterExpander(id uint, hashID string, f ExpanderFunc) error {
+	if hashID == "" || strings.Contains(hashID, "_") {
+		return fmt.Errorf("%w: %q is not a valid HASH_ID", ErrInvalidSuiteID, hashID)
+	}
+	if f == nil {
+		return errors.New("expander: nil constructor")
+	}
+	expandersMu.Lock()
+	defer expandersMu.Unlock()
+	if _, err := parseBuiltinExpander(hashID); err == nil {
+		return fmt.Errorf("%w: %v", ErrExpanderRegistered, hashID)
+	}
+	for i, r := range otherExpanders {
+		if i == id || r.hashID == hashID {
+			return fmt.Errorf("%w: %v", ErrExpanderRegistered, hashID)
+		}
+	}
+	otherExpanders[id] = registeredExpander{hashID, f}
+	return nil
+}
+
+func lookupExpander(id uint) (registeredExpander, bool) {
+	expandersMu.RLock()
+	defer expandersMu.RUnlock()
+	r, ok := otherExpanders[id]
+	return r, ok
+}
+
+func lookupExpanderName(hashID string) (uint, bool) {
+	expandersMu.RLock()
+	defer expandersMu.RUnlock()
+	for id, r := range otherExpanders {
+		if r.hashID == hashID {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// ExpandFunc is an adapter to use a function as an Expander. Its hasher
+// buffers the message written, so it does not stream.
+type ExpandFunc func(msg []byte, n uint) ([]byte, error)
+
+// Expand calls f(msg, n) and panics on error.
+func (f ExpandFunc) Expand(msg []byte, n uint) []byte { return mustExpand(f(msg, n)) }
+
+// ExpandMessage calls f(msg, n).
+func (f ExpandFunc) ExpandMessage(msg []byte, n uint) ([]byte, error) { return f(msg, n) }
+
+// NewHasher returns an ExpandHasher that calls f on the data written.
+func (f ExpandFunc) NewHasher() ExpandHasher { return &funcHasher{f: f} }
+
+type funcHasher struct {
+	f   ExpandFunc
+	msg []byte
+}
+
+func (h *funcHasher) Write(p []byte) (int, error) { h.msg = append(h.msg, p...); return len(p), nil }
+func (h *funcHasher) Reset()                      { h.msg = h.msg[:0] }
+func (h *funcHasher) Sum(n uint) ([]byte, error)  { return h.f(h.msg, n) }
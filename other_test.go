@@ -0,0 +1,148 @@
+package h2c_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+	"github.com/armfazh/h2c-go-ref/curve"
+	M "github.com/armfazh/h2c-go-ref/mapping"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	hkdfID   = 1
+	keccakID = 2
+)
+
+func newHKDF(dst []byte, k uint) (h2c.Expander, error) {
+	return h2c.ExpandFunc(func(msg []byte, n uint) ([]byte, error) {
+		out := make([]byte, n)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, msg, nil, dst), out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}), nil
+}
+
+func newKeccak(dst []byte, k uint) (h2c.Expander, error) {
+	return h2c.NewExpanderXMD(sha3.NewLegacyKeccak256, dst, k)
+}
+
+func init() {
+	if err := h2c.RegisterExpander(hkdfID, "HKDF:SHA-256", newHKDF); err != nil {
+		panic(err)
+	}
+	if err := h2c.RegisterExpander(keccakID, "XMD:KECCAK-256", newKeccak); err != nil {
+		panic(err)
+	}
+}
+
+func TestOtherExpander(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-HKDF")
+	msg := []byte("abc")
+	desc, err := h2c.ParseExpanderDesc("HKDF:SHA-256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (h2c.ExpanderDesc{Type: h2c.OTHER, ID: hkdfID}); desc != want || desc.String() != "HKDF:SHA-256" {
+		t.Fatalf("got:  %v\nwant: %v", desc, want)
+	}
+
+	b := h2c.SuiteBuilder{E: curve.P256, K: 128, Exp: desc, Map: M.MapDescriptor{ID: M.SSWU, Z: -10}, RO: true}
+	want, err := b.Get(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := h2c.SuiteID("P256_HKDF:SHA-256_SSWU_RO_")
+	c, err := id.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Expander != h2c.OTHER || c.ID() != id {
+		t.Fatalf("got: %+v", c)
+	}
+	got, err := id.Get(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if P, Q := got.Hash(msg), want.Hash(msg); !P.IsEqual(Q) {
+		t.Fatalf("got:  %v\nwant: %v", P, Q)
+	}
+	h := got.NewHasher()
+	_, _ = h.Write(msg)
+	if P, err := h.Sum(); err != nil {
+		t.Fatal(err)
+	} else if Q := want.Hash(msg); !P.IsEqual(Q) {
+		t.Fatalf("got:  %v\nwant: %v", P, Q)
+	}
+
+	for _, v := range []struct {
+		id     uint
+		hashID string
+		err    error
+	}{
+		{hkdfID, "HKDF:SHA-512", h2c.ErrExpanderRegistered},
+		{hkdfID + 1, "HKDF:SHA-256", h2c.ErrExpanderRegistered},
+		{hkdfID + 1, "XMD:SHA-256", h2c.ErrExpanderRegistered},
+		{hkdfID + 1, "HKDF_SHA-256", h2c.ErrInvalidSuiteID},
+	} {
+		if err := h2c.RegisterExpander(v.id, v.hashID, newHKDF); !errors.Is(err, v.err) {
+			t.Fatalf("got:  %v\nwant: %v", err, v.err)
+		}
+	}
+}
+
+func TestExpanderXMDKeccak(t *testing.T) {
+	id := h2c.SuiteID("secp256k1_XMD:KECCAK-256_SSWU_RO_")
+	hashToCurve, err := id.Get([]byte("QUUX-V01-CS02-with-" + id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	E := hashToCurve.GetCurve()
+	if P := hashToCurve.Hash([]byte("abc")); !E.IsOnCurve(P) {
+		t.Fatalf("got: %v", P)
+	}
+	if _, err := newKeccak([]byte("DST"), 192); !errors.Is(err, h2c.ErrHashSize) {
+		t.Fatalf("got:  %v\nwant: %v", err, h2c.ErrHashSize)
+	}
+}
+
+func TestExpanderZcash(t *testing.T) {
+	// The expansion to 128 bytes computed with Python's hashlib following
+	// hash_to_field of the pasta_curves crate.
+	dst := []byte("z.cash:test-pallas_XMD:BLAKE2b_SSWU_RO_")
+	msg := []byte("abc")
+	want, _ := hex.DecodeString("19700276a22000e8833fab8a67854b6c19798d83d2984b6e23ba56f9f1abc7d4" +
+		"92b60009636d5de6fd71c0b52e5b90dd2322ef895eb68536607014d1508c69b2" +
+		"ae3802157c501ed0b0d594a08a15d6e785d2d2896860808da3c8de6b08420531" +
+		"2fddda3b72dd1aac967c9aeb83518ee0a1e32b0860523ad0443389b306902a24")
+
+	desc, err := h2c.ParseExpanderDesc("XMD:BLAKE2b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desc != (h2c.ExpanderDesc{Type: h2c.OTHER, ID: h2c.XMDBLAKE2bZcash}) {
+		t.Fatalf("got: %v", desc)
+	}
+	if typ, name := desc.Variant(); typ != h2c.XMD || name != "BLAKE2b" {
+		t.Fatalf("got: %v %v want: %v BLAKE2b", typ, name, h2c.XMD)
+	}
+	exp, err := desc.Get(dst, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := exp.Expand(msg, 128); !bytes.Equal(got, want) {
+		t.Fatalf("got:  %x\nwant: %x", got, want)
+	}
+	h := exp.NewHasher()
+	_, _ = h.Write(msg)
+	if got, err := h.Sum(128); err != nil || !bytes.Equal(got, want) {
+		t.Fatalf("got:  %x\nwant: %x", got, want)
+	}
+}
@@ -0,0 +1,226 @@
+// Package ristretto255 implements the ristretto255 prime-order group as
+// specified in RFC 9496. Elements are represented by points of edwards25519,
+// and two points represent the same element if they differ by a point of
+// order four.
+package ristretto255
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/armfazh/h2c-go-ref/curve"
+	Q "github.com/armfazh/h2c-go-ref/internal/quotient"
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Lengths in bytes of encodings.
+const (
+	EncodingLen     = 32 // EncodingLen is the length of an encoded element.
+	UniformBytesLen = 64 // UniformBytesLen is the input length of FromUniformBytes.
+)
+
+// Errors returned when decoding elements.
+var (
+	ErrInvalidEncoding = errors.New("ristretto255: invalid encoding")
+	ErrInvalidLength   = errors.New("ristretto255: invalid length")
+)
+
+// Constants of Section 4.1 of RFC 9496.
+const (
+	sqrtM1         = "19681161376707505956807079304988542015446066515923890162744021073123829784752"
+	sqrtADMinusOne = "25063068953384623474111414158702152701244531502492656460079210482610430750235"
+	invSqrtAMinusD = "54469307008909316920995813868745141605393597292927456921205312896311721017578"
+	generatorX     = "0x216936d3cd6e53fec0a4e231fdd6dc5c692cc7609525a7b2c9562d608f25d51a"
+	generatorY     = "0x6666666666666666666666666666666666666666666666666666666666666658"
+)
+
+type params struct {
+	E                         C.T
+	F                         GF.Field
+	d, sqrtM1, sqrtADMinusOne GF.Elt
+	invSqrtAMinusD            GF.Elt
+	oneMinusDSq, dMinusOneSq  GF.Elt
+	pMinus5Div8               *big.Int
+	generator                 C.Point
+}
+
+var r255 = newParams()
+
+func newParams() *params {
+	e, err := curve.Edwards25519.Get()
+	if err != nil {
+		panic(err)
+	}
+	E := e.(C.T)
+	F := E.F
+	p := &params{E: E, F: F, d: E.D}
+	p.sqrtM1 = F.Elt(sqrtM1)
+	p.sqrtADMinusOne = F.Elt(sqrtADMinusOne)
+	p.invSqrtAMinusD = F.Elt(invSqrtAMinusD)
+	p.oneMinusDSq = F.Sub(F.One(), F.Sqr(p.d))
+	p.dMinusOneSq = F.Sqr(F.Sub(p.d, F.One()))
+	p.pMinus5Div8 = new(big.Int).Rsh(F.P(), 3) // (p-5)/8, since p = 5 mod 8.
+	p.generator = E.NewPoint(F.Elt(generatorX), F.Elt(generatorY))
+	return p
+}
+
+// Curve returns edwards25519, the curve whose points represent elements.
+func Curve() C.EllCurve { return r255.E }
+
+// sqrtRatioM1 returns (true, sqrt(u/v)) if u/v is square, and otherwise
+// (false, sqrt(SQRT_M1*u/v)); the root is non-negative.
+func sqrtRatioM1(u, v GF.Elt) (bool, GF.Elt) {
+	F := r255.F
+	v3 := F.Mul(F.Sqr(v), v)
+	v7 := F.Mul(F.Sqr(v3), v)
+	r := F.Mul(F.Mul(u, v3), F.Exp(F.Mul(u, v7), r255.pMinus5Div8))
+	check := F.Mul(v, F.Sqr(r))
+
+	correctSignSqrt := F.AreEqual(check, u)
+	flippedSignSqrt := F.AreEqual(check, F.Neg(u))
+	flippedSignSqrtI := F.AreEqual(check, F.Neg(F.Mul(u, r255.sqrtM1)))
+	if flippedSignSqrt || flippedSignSqrtI {
+		r = F.Mul(r, r255.sqrtM1)
+	}
+	return correctSignSqrt || flippedSignSqrt, Q.Abs(F, r)
+}
+
+// Element is an element of the ristretto255 group.
+type Element struct{ p C.Point }
+
+// NewElement returns the element represented by a point of edwards25519.
+// The point must belong to the subgroup generated by the elements of the
+// group, e.g., a point returned by Point.
+func NewElement(p C.Point) *Element { return &Element{p.Copy()} }
+
+// Identity returns the identity element.
+func Identity() *Element { return &Element{r255.E.Identity()} }
+
+// Generator returns the canonical generator of the group.
+func Generator() *Element { return &Element{r255.generator.Copy()} }
+
+// Point returns a point of edwards25519 that represents the element.
+func (e *Element) Point() C.Point { return e.p.Copy() }
+
+// Add returns e+f.
+func (e *Element) Add(f *Element) *Element { return &Element{r255.E.Add(e.p, f.p)} }
+
+// ScalarMult returns k*e.
+func (e *Element) ScalarMult(k *big.Int) *Element {
+	return &Element{r255.E.ScalarMult(e.p, k)}
+}
+
+// Equal returns true if both elements are equal, that is, if their
+// representatives differ by a point of order four.
+func (e *Element) Equal(f *Element) bool {
+	F := r255.F
+	x1, y1, x2, y2 := e.p.X(), e.p.Y(), f.p.X(), f.p.Y()
+	return F.AreEqual(F.Mul(x1, y2), F.Mul(y1, x2)) ||
+		F.AreEqual(F.Mul(y1, y2), F.Mul(x1, x2))
+}
+
+// Encode returns the canonical encoding of the element.
+func (e *Element) Encode() []byte {
+	F := r255.F
+	// Affine coordinates, so Z = 1 and T = x*y.
+	x0, y0, z0 := e.p.X(), e.p.Y(), F.One()
+	t0 := F.Mul(x0, y0)
+
+	u1 := F.Mul(F.Add(z0, y0), F.Sub(z0, y0))
+	u2 := F.Mul(x0, y0)
+	_, invsqrt := sqrtRatioM1(F.One(), F.Mul(u1, F.Sqr(u2)))
+	den1 := F.Mul(invsqrt, u1)
+	den2 := F.Mul(invsqrt, u2)
+	zInv := F.Mul(F.Mul(den1, den2), t0)
+	ix0 := F.Mul(x0, r255.sqrtM1)
+	iy0 := F.Mul(y0, r255.sqrtM1)
+	enchantedDenominator := F.Mul(den1, r255.invSqrtAMinusD)
+
+	x, y, denInv := x0, y0, den2
+	if Q.IsNegative(F, F.Mul(t0, zInv)) {
+		x, y, denInv = iy0, ix0, enchantedDenominator
+	}
+	if Q.IsNegative(F, F.Mul(x, zInv)) {
+		y = F.Neg(y)
+	}
+	s := Q.Abs(F, F.Mul(denInv, F.Sub(z0, y)))
+	return Q.FieldToBytes(s, EncodingLen)
+}
+
+// Decode returns the element encoded by b, or an error if b is not the
+// canonical encoding of an element.
+func Decode(b []byte) (*Element, error) {
+	if len(b) != EncodingLen {
+		return nil, ErrInvalidLength
+	}
+	F := r255.F
+	n := Q.BytesToInt(b)
+	if n.Cmp(F.P()) >= 0 {
+		return nil, ErrInvalidEncoding
+	}
+	s := F.Elt(n)
+	if Q.IsNegative(F, s) {
+		return nil, ErrInvalidEncoding
+	}
+	ss := F.Sqr(s)
+	u1 := F.Sub(F.One(), ss)
+	u2 := F.Add(F.One(), ss)
+	u2Sqr := F.Sqr(u2)
+	v := F.Sub(F.Neg(F.Mul(r255.d, F.Sqr(u1))), u2Sqr)
+	wasSquare, invsqrt := sqrtRatioM1(F.One(), F.Mul(v, u2Sqr))
+	denX := F.Mul(invsqrt, u2)
+	denY := F.Mul(F.Mul(invsqrt, denX), v)
+	x := Q.Abs(F, F.Mul(F.Add(s, s), denX))
+	y := F.Mul(u1, denY)
+	t := F.Mul(x, y)
+	if !wasSquare || Q.IsNegative(F, t) || F.IsZero(y) {
+		return nil, ErrInvalidEncoding
+	}
+	return &Element{r255.E.NewPoint(x, y)}, nil
+}
+
+// Map is the one-way map MAP of Section 4.3.4 of RFC 9496; it returns a
+// point of edwards25519 that represents an element.
+func Map(t GF.Elt) C.Point {
+	F := r255.F
+	one := F.One()
+	d := r255.d
+	r := F.Mul(r255.sqrtM1, F.Sqr(t))
+	u := F.Mul(F.Add(r, one), r255.oneMinusDSq)
+	v := F.Mul(F.Sub(F.Neg(one), F.Mul(r, d)), F.Add(r, d))
+	wasSquare, s := sqrtRatioM1(u, v)
+	c := F.Neg(one)
+	if !wasSquare {
+		s = F.Neg(Q.Abs(F, F.Mul(s, t)))
+		c = r
+	}
+	N := F.Sub(F.Mul(F.Mul(c, F.Sub(r, one)), r255.dMinusOneSq), v)
+	ss := F.Sqr(s)
+	w0 := F.Mul(F.Add(s, s), v)
+	w1 := F.Mul(N, r255.sqrtADMinusOne)
+	w2 := F.Sub(one, ss)
+	w3 := F.Add(one, ss)
+	// The extended coordinates (w0*w3 : w2*w1 : w1*w3 : w0*w2) in affine form.
+	return r255.E.NewPoint(F.Mul(w0, F.Inv(w1)), F.Mul(w2, F.Inv(w3)))
+}
+
+// DecodeField returns the field element obtained from 32 bytes in
+// little-endian order after clearing the most significant bit, as used by
+// FromUniformBytes.
+func DecodeField(b []byte) GF.Elt {
+	n := Q.BytesToInt(b)
+	n.SetBit(n, 255, 0)
+	return r255.F.Elt(n.Mod(n, r255.F.P()))
+}
+
+// FromUniformBytes maps 64 uniformly random bytes to an element following
+// Section 4.3.4 of RFC 9496.
+func FromUniformBytes(b []byte) (*Element, error) {
+	if len(b) != UniformBytesLen {
+		return nil, ErrInvalidLength
+	}
+	P1 := Map(DecodeField(b[:32]))
+	P2 := Map(DecodeField(b[32:]))
+	return &Element{r255.E.Add(P1, P2)}, nil
+}
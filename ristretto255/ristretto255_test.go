@@ -0,0 +1,105 @@
+package ristretto255_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/armfazh/h2c-go-ref/ristretto255"
+)
+
+type vectors struct {
+	Multiples        []string `json:"multiples"`
+	Invalid          []string `json:"invalid"`
+	FromUniformBytes []struct {
+		I string `json:"I"`
+		O string `json:"O"`
+	} `json:"fromUniformBytes"`
+}
+
+func readVectors(t *testing.T) (v vectors) {
+	b, err := ioutil.ReadFile("testdata/rfc9496.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestMultiples(t *testing.T) {
+	v := readVectors(t)
+	B := ristretto255.Generator()
+	P := ristretto255.Identity()
+	for i, s := range v.Multiples {
+		want := mustHex(t, s)
+		if got := P.Encode(); !bytes.Equal(got, want) {
+			t.Fatalf("%v*B\ngot:  %x\nwant: %x", i, got, want)
+		}
+		Q, err := ristretto255.Decode(want)
+		if err != nil {
+			t.Fatalf("%v*B: %v", i, err)
+		}
+		if !Q.Equal(P) || !bytes.Equal(Q.Encode(), want) {
+			t.Fatalf("%v*B: decoding mismatch", i)
+		}
+		if R := B.ScalarMult(big.NewInt(int64(i))); !R.Equal(P) {
+			t.Fatalf("%v*B: scalar multiplication mismatch", i)
+		}
+		P = P.Add(B)
+	}
+}
+
+func TestInvalidEncodings(t *testing.T) {
+	for _, s := range readVectors(t).Invalid {
+		if _, err := ristretto255.Decode(mustHex(t, s)); !errors.Is(err, ristretto255.ErrInvalidEncoding) {
+			t.Fatalf("encoding: %v\ngot:  %v\nwant: %v", s, err, ristretto255.ErrInvalidEncoding)
+		}
+	}
+	if _, err := ristretto255.Decode(make([]byte, 31)); !errors.Is(err, ristretto255.ErrInvalidLength) {
+		t.Fatalf("got:  %v\nwant: %v", err, ristretto255.ErrInvalidLength)
+	}
+}
+
+func TestFromUniformBytes(t *testing.T) {
+	for _, v := range readVectors(t).FromUniformBytes {
+		P, err := ristretto255.FromUniformBytes(mustHex(t, v.I))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := P.Encode(), mustHex(t, v.O); !bytes.Equal(got, want) {
+			t.Fatalf("input: %v\ngot:  %x\nwant: %x", v.I, got, want)
+		}
+	}
+	if _, err := ristretto255.FromUniformBytes(make([]byte, 32)); !errors.Is(err, ristretto255.ErrInvalidLength) {
+		t.Fatalf("got:  %v\nwant: %v", err, ristretto255.ErrInvalidLength)
+	}
+}
+
+// Representatives that differ by a point of order four are equal.
+func TestEqual(t *testing.T) {
+	E := ristretto255.Curve()
+	F := E.Field()
+	T4 := E.NewPoint(F.Elt("0x2b8324804fc1df0b2b4d00993dfbd7a72f431806ad2fe478c4ee1b274a0ea0b0"), F.Zero())
+	B := ristretto255.Generator()
+	P := ristretto255.NewElement(E.Add(B.Point(), T4))
+	if !P.Equal(B) || !bytes.Equal(P.Encode(), B.Encode()) {
+		t.Fatal("representatives of the same element are not equal")
+	}
+	if P.Equal(B.Add(B)) {
+		t.Fatal("different elements are equal")
+	}
+}
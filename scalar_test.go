@@ -0,0 +1,89 @@
+package h2c_test
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+func TestHashToScalar(t *testing.T) {
+	id := h2c.BLS12381G1_XMDSHA256_SSWU_RO_
+	dst := []byte("HashToScalar-QUUX-V01-CS02-with-" + id)
+	msg := []byte("abc")
+	hashToScalar, err := id.GetHashToScalar(dst, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	S := hashToScalar.GetScalarField()
+
+	// L is derived from the order of the group, that is, ceil((255+128)/8) = 48.
+	exp, err := h2c.ExpanderDesc{Type: h2c.XMD, ID: uint(crypto.SHA256)}.Get(dst, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := h2c.NewHashToField(GF.NewFp("r", S.Order()), exp, 48)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := h.Hash(msg, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := hashToScalar.Hash(msg)
+	if got.Polynomial()[0].Cmp(want[0].Polynomial()[0]) != 0 {
+		t.Fatalf("got:  %v\nwant: %v", got, want[0])
+	}
+
+	hashToCurve, err := id.Get([]byte("QUUX-V01-CS02-with-" + id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared := hashToCurve.GetHashToScalar().Hash(msg)
+	if got.Polynomial()[0].Cmp(shared.Polynomial()[0]) == 0 {
+		t.Fatal("scalars are not domain-separated")
+	}
+
+	if _, err := h2c.SuiteID("P255_XMD:SHA-256_SSWU_RO_").GetHashToScalar(dst, 0); !errors.Is(err, h2c.ErrUnsupportedSuite) {
+		t.Fatalf("got:  %v\nwant: %v", err, h2c.ErrUnsupportedSuite)
+	}
+}
+
+// TestHashToScalarGroup checks the secret key derived by DeriveKeyPair in
+// Appendix A.1.1 of RFC 9497 (ristretto255-SHA512, OPRF mode), which is
+// HashToScalar(seed || I2OSP(len(info), 2) || info || I2OSP(0, 1)).
+func TestHashToScalarGroup(t *testing.T) {
+	id := h2c.Ristretto255_XMDSHA512_R255MAP_RO_
+	dst := []byte("DeriveKeyPair" + "OPRFV1-\x00-ristretto255-SHA512")
+	seed := bytes.Repeat([]byte{0xa3}, 32)
+	info := []byte("test key")
+	msg := append(append(append(seed, 0, byte(len(info))), info...), 0)
+	skSm, err := hex.DecodeString("5ebcea5ee37023ccb9fc2d2019f9d7737be85591ae8652ffa9ef0f4d37063b0e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The scalar is encoded in little-endian order.
+	for i, j := 0, len(skSm)-1; i < j; i, j = i+1, j-1 {
+		skSm[i], skSm[j] = skSm[j], skSm[i]
+	}
+	want := new(big.Int).SetBytes(skSm)
+
+	hashToScalar, err := id.GetHashToScalar(dst, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashToCurve, err := id.Get(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range []h2c.HashToScalar{hashToScalar, hashToCurve.GetHashToScalar()} {
+		if got := h.Hash(msg).Polynomial()[0]; got.Cmp(want) != 0 {
+			t.Fatalf("got:  %x\nwant: %x", got, want)
+		}
+	}
+}
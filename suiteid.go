@@ -0,0 +1,168 @@
+package h2c
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	C "github.com/armfazh/h2c-go-ref/curve"
+	M "github.com/armfazh/h2c-go-ref/mapping"
+)
+
+// ErrInvalidSuiteID is returned when a SuiteID is not well-formed.
+var ErrInvalidSuiteID = errors.New("invalid suite identifier")
+
+// SuiteComponents are the parts of a suite identifier as described in
+// Section 8.10 of RFC 9380, that is,
+//
+//	CURVE_ID || "_" || HASH_ID || "_" || MAP_ID || "_" || ENC_VAR || "_"
+//
+// where HASH_ID is the expander tag and the hash name separated by a colon, or
+// the HASH_ID of an expander registered with RegisterExpander.
+type SuiteComponents struct {
+	Curve    string       // Curve is the CURVE_ID, e.g. "P256".
+	Expander ExpanderType // Expander is XMD, XOF, or OTHER for registered expanders.
+	Hash     string       // Hash is the name of the hash function, e.g. "SHA-256", or the HASH_ID for OTHER.
+	Map      string       // Map is the MAP_ID, e.g. "SSWU".
+	RO       bool         // RO is true for the "RO" encoding type, false for "NU".
+}
+
+// Parse decomposes a SuiteID into its components. Only the syntax of the
+// identifier is validated, so the components might not be supported.
+func (id SuiteID) Parse() (c SuiteComponents, err error) {
+	s := string(id)
+	if !strings.HasSuffix(s, "_") {
+		return c, fmt.Errorf("%w: %q missing trailing underscore", ErrInvalidSuiteID, id)
+	}
+	parts := strings.Split(strings.TrimSuffix(s, "_"), "_")
+	n := len(parts)
+	if n < 4 {
+		return c, fmt.Errorf("%w: %q", ErrInvalidSuiteID, id)
+	}
+	curve, hashID, mapID, encVar := strings.Join(parts[:n-3], "_"), parts[n-3], parts[n-2], parts[n-1]
+	if curve == "" || mapID == "" {
+		return c, fmt.Errorf("%w: %q", ErrInvalidSuiteID, id)
+	}
+	c.Curve, c.Map = curve, mapID
+
+	tag := strings.SplitN(hashID, ":", 2)
+	if _, ok := lookupExpanderName(hashID); ok {
+		c.Expander, c.Hash = OTHER, hashID
+	} else if len(tag) != 2 || tag[1] == "" {
+		return c, fmt.Errorf("%w: %q bad HASH_ID", ErrInvalidSuiteID, id)
+	} else {
+		switch tag[0] {
+		case XMD.String():
+			c.Expander = XMD
+		case XOF.String():
+			c.Expander = XOF
+		default:
+			return c, fmt.Errorf("%w: %q bad expander tag", ErrInvalidSuiteID, id)
+		}
+		c.Hash = tag[1]
+	}
+
+	switch encVar {
+	case "RO":
+		c.RO = true
+	case "NU":
+		c.RO = false
+	default:
+		return c, fmt.Errorf("%w: %q bad encoding type", ErrInvalidSuiteID, id)
+	}
+	return c, nil
+}
+
+// ID returns the SuiteID corresponding to the components.
+func (c SuiteComponents) ID() SuiteID {
+	enc := "NU"
+	if c.RO {
+		enc = "RO"
+	}
+	return SuiteID(c.Curve + "_" + c.hashID() + "_" + c.Map + "_" + enc + "_")
+}
+
+func (c SuiteComponents) hashID() string {
+	if c.Expander == OTHER {
+		return c.Hash
+	}
+	return c.Expander.String() + ":" + c.Hash
+}
+
+// Builder assembles a SuiteBuilder from the components, provided that the
+// curve, the hash function, and the mapping are known.
+func (c SuiteComponents) Builder() (SuiteBuilder, error) {
+	e, ok := knownCurves[c.Curve]
+	if !ok {
+		return SuiteBuilder{}, fmt.Errorf("%w: %v", C.ErrUnsupported, c.Curve)
+	}
+	exp, err := ParseExpanderDesc(c.hashID())
+	if err != nil {
+		return SuiteBuilder{}, err
+	}
+	m, err := c.mapping(e)
+	if err != nil {
+		return SuiteBuilder{}, err
+	}
+	return SuiteBuilder{E: e.ID, K: e.K, Exp: exp, Map: m, L: e.L[exp.String()], RO: c.RO, Clear: e.Clear}, nil
+}
+
+func (c SuiteComponents) mapping(e knownCurve) (M.MapDescriptor, error) {
+	if e.Group.ID.IsGroupMap() {
+		if c.Map == e.Group.ID.String() {
+			return e.Group, nil
+		}
+		return M.MapDescriptor{}, fmt.Errorf("%w: %v for %v", M.ErrUnsupported, c.Map, c.Curve)
+	}
+	switch c.Map {
+	case M.SSWU.String():
+		if e.SSWU.Z == nil {
+			break
+		}
+		return e.SSWU, nil
+	case M.SVDW.String():
+		return M.MapDescriptor{ID: M.SVDW}, nil
+	case M.ELL2.String():
+		return M.MapDescriptor{ID: M.ELL2}, nil
+	}
+	return M.MapDescriptor{}, fmt.Errorf("%w: %v for %v", M.ErrUnsupported, c.Map, c.Curve)
+}
+
+// knownCurve holds the parameters recommended by RFC 9380 for a curve.
+type knownCurve struct {
+	ID   C.ID
+	Name string // Name is the curve name used in test vectors.
+	K    uint
+	SSWU M.MapDescriptor // SSWU holds Z and the isogeny used by the SSWU method.
+	// Group is the map of a prime-order group built on the curve, which is
+	// the only map allowed for such a group.
+	Group M.MapDescriptor
+	Clear func() (C.CofactorClearing, error) // Clear is the cofactor clearing method, if any.
+	// L overrides, for some HASH_IDs, the length in bytes per field element
+	// derived from K.
+	L map[string]uint
+}
+
+// zcashL is the length used by Zcash to hash to the Pasta curves.
+var zcashL = map[string]uint{"XMD:BLAKE2b": 64}
+
+var knownCurves = map[string]knownCurve{
+	"P256":         {ID: C.P256, Name: "NIST P-256", K: 128, SSWU: M.MapDescriptor{ID: M.SSWU, Z: -10}},
+	"P384":         {ID: C.P384, Name: "NIST P-384", K: 192, SSWU: M.MapDescriptor{ID: M.SSWU, Z: -12}},
+	"P521":         {ID: C.P521, Name: "NIST P-521", K: 256, SSWU: M.MapDescriptor{ID: M.SSWU, Z: -4}},
+	"curve25519":   {ID: C.Curve25519, Name: "curve25519", K: 128},
+	"edwards25519": {ID: C.Edwards25519, Name: "edwards25519", K: 128},
+	"curve448":     {ID: C.Curve448, Name: "curve448", K: 224},
+	"edwards448":   {ID: C.Edwards448, Name: "edwards448", K: 224},
+	"secp256k1":    {ID: C.SECP256K1, Name: "secp256k1", K: 128, SSWU: M.MapDescriptor{ID: M.SSWU, Z: -11, Iso: C.GetSECP256K1Isogeny}},
+	"BLS12381G1":   {ID: C.BLS12381G1, Name: "BLS12-381 G1", K: 128, SSWU: M.MapDescriptor{ID: M.SSWU, Z: 11, Iso: C.GetBLS12381G1Isogeny}},
+	"BLS12381G2":   {ID: C.BLS12381G2, Name: "BLS12-381 G2", K: 128, SSWU: M.MapDescriptor{ID: M.SSWU, Z: []interface{}{-2, -1}, Iso: C.GetBLS12381G2Isogeny}},
+	"ristretto255": {ID: C.Edwards25519, Name: "ristretto255", K: 128, Group: M.MapDescriptor{ID: M.R255MAP}},
+	"decaf448":     {ID: C.Edwards448, Name: "decaf448", K: 224, Group: M.MapDescriptor{ID: M.D448MAP}},
+	"BN254G1":      {ID: C.BN254G1, Name: "BN254 G1", K: 128},
+	"BN254G2":      {ID: C.BN254G2, Name: "BN254 G2", K: 128, Clear: C.GetBN254G2CofactorClearing},
+	"BLS12377G1":   {ID: C.BLS12377G1, Name: "BLS12-377 G1", K: 128, SSWU: M.MapDescriptor{ID: M.SSWU, Z: -11, Iso: C.GetBLS12377G1Isogeny}, Clear: C.GetBLS12377G1CofactorClearing},
+	"BLS12377G2":   {ID: C.BLS12377G2, Name: "BLS12-377 G2", K: 128, Clear: C.GetBLS12377G2CofactorClearing},
+	"pallas":       {ID: C.Pallas, Name: "pallas", K: 128, SSWU: M.MapDescriptor{ID: M.SSWU, Z: -13, Iso: C.GetPallasIsogeny}, L: zcashL},
+	"vesta":        {ID: C.Vesta, Name: "vesta", K: 128, SSWU: M.MapDescriptor{ID: M.SSWU, Z: -13, Iso: C.GetVestaIsogeny}, L: zcashL},
+}
@@ -0,0 +1,147 @@
+package h2c_test
+
+import (
+	"errors"
+	"testing"
+
+	h2c "github.com/armfazh/h2c-go-ref"
+)
+
+var registeredSuites = []h2c.SuiteID{
+	h2c.P256_XMDSHA256_SSWU_NU_,
+	h2c.P256_XMDSHA256_SSWU_RO_,
+	h2c.P384_XMDSHA384_SSWU_NU_,
+	h2c.P384_XMDSHA384_SSWU_RO_,
+	h2c.P521_XMDSHA512_SSWU_NU_,
+	h2c.P521_XMDSHA512_SSWU_RO_,
+	h2c.Curve25519_XMDSHA512_ELL2_NU_,
+	h2c.Curve25519_XMDSHA512_ELL2_RO_,
+	h2c.Edwards25519_XMDSHA512_ELL2_NU_,
+	h2c.Edwards25519_XMDSHA512_ELL2_RO_,
+	h2c.Curve448_XOFSHAKE256_ELL2_NU_,
+	h2c.Curve448_XOFSHAKE256_ELL2_RO_,
+	h2c.Edwards448_XOFSHAKE256_ELL2_NU_,
+	h2c.Edwards448_XOFSHAKE256_ELL2_RO_,
+	h2c.Secp256k1_XMDSHA256_SSWU_NU_,
+	h2c.Secp256k1_XMDSHA256_SSWU_RO_,
+	h2c.BLS12381G1_XMDSHA256_SSWU_NU_,
+	h2c.BLS12381G1_XMDSHA256_SSWU_RO_,
+	h2c.BLS12381G2_XMDSHA256_SSWU_NU_,
+	h2c.BLS12381G2_XMDSHA256_SSWU_RO_,
+	h2c.Ristretto255_XMDSHA512_R255MAP_RO_,
+	h2c.Decaf448_XOFSHAKE256_D448MAP_RO_,
+	h2c.BN254G1_XMDSHA256_SVDW_NU_,
+	h2c.BN254G1_XMDSHA256_SVDW_RO_,
+	h2c.BN254G2_XMDSHA256_SVDW_NU_,
+	h2c.BN254G2_XMDSHA256_SVDW_RO_,
+	h2c.BLS12377G1_XMDSHA256_SSWU_NU_,
+	h2c.BLS12377G1_XMDSHA256_SSWU_RO_,
+	h2c.BLS12377G2_XMDSHA256_SVDW_NU_,
+	h2c.BLS12377G2_XMDSHA256_SVDW_RO_,
+	h2c.Pallas_XMDBLAKE2b_SSWU_NU_,
+	h2c.Pallas_XMDBLAKE2b_SSWU_RO_,
+	h2c.Pallas_XMDSHA256_SSWU_NU_,
+	h2c.Pallas_XMDSHA256_SSWU_RO_,
+	h2c.Vesta_XMDBLAKE2b_SSWU_NU_,
+	h2c.Vesta_XMDBLAKE2b_SSWU_RO_,
+	h2c.Vesta_XMDSHA256_SSWU_NU_,
+	h2c.Vesta_XMDSHA256_SSWU_RO_,
+}
+
+func TestParseSuiteID(t *testing.T) {
+	c, err := h2c.BLS12381G2_XMDSHA256_SSWU_RO_.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := h2c.SuiteComponents{Curve: "BLS12381G2", Expander: h2c.XMD, Hash: "SHA-256", Map: "SSWU", RO: true}
+	if c != want {
+		t.Fatalf("got:  %+v\nwant: %+v", c, want)
+	}
+
+	for _, id := range []h2c.SuiteID{
+		"", "P256", "P256_XMD:SHA-256_SSWU_RO", "P256_XMD_SSWU_RO_", "P256_XMD:_SSWU_RO_",
+		"P256_XYZ:SHA-256_SSWU_RO_", "P256_XMD:SHA-256_SSWU_XX_", "_XMD:SHA-256_SSWU_RO_",
+	} {
+		if _, err := id.Parse(); !errors.Is(err, h2c.ErrInvalidSuiteID) {
+			t.Fatalf("suite: %q\ngot:  %v\nwant: %v", id, err, h2c.ErrInvalidSuiteID)
+		}
+	}
+}
+
+func TestResolveSuiteID(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02")
+	msg := []byte("abc")
+	for _, id := range registeredSuites {
+		c, err := id.Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.ID(); got != id {
+			t.Fatalf("got:  %v\nwant: %v", got, id)
+		}
+		b, err := c.Builder()
+		if err != nil {
+			t.Fatalf("suite: %v: %v", id, err)
+		}
+		resolved, err := b.Get(dst)
+		if err != nil {
+			t.Fatalf("suite: %v: %v", id, err)
+		}
+		registered, err := id.Get(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resolved.IsRandomOracle() != registered.IsRandomOracle() {
+			t.Fatalf("suite: %v mismatch on encoding type", id)
+		}
+		if P, Q := resolved.Hash(msg), registered.Hash(msg); !P.IsEqual(Q) {
+			t.Fatalf("suite: %v\ngot:  %v\nwant: %v", id, P, Q)
+		}
+	}
+
+	for _, id := range []h2c.SuiteID{
+		"P256_XMD:SHA-512_SSWU_RO_",
+		"edwards25519_XOF:SHAKE128_ELL2_RO_",
+		"P384_XMD:SHA-512_SVDW_NU_",
+		"P256_XOF:BLAKE2XB_SSWU_RO_",
+		"secp256k1_XOF:BLAKE2XS_SSWU_NU_",
+		"P256_XOF:TUPLEHASH128_SSWU_RO_",
+		"P521_XOF:PARALLELHASH256_SSWU_NU_",
+		"edwards25519_XOF:BLAKE3_ELL2_RO_",
+		"P256_XOF:ASCONXOF128_SSWU_RO_",
+		"secp256k1_XOF:ASCONCXOF128_SSWU_NU_",
+	} {
+		hashToCurve, err := id.Get(dst)
+		if err != nil {
+			t.Fatalf("suite: %v: %v", id, err)
+		}
+		E := hashToCurve.GetCurve()
+		if P := hashToCurve.Hash(msg); !E.IsOnCurve(P) {
+			t.Fatalf("suite: %v\ngot: %v", id, P)
+		}
+	}
+
+	for _, id := range []h2c.SuiteID{
+		"P255_XMD:SHA-256_SSWU_RO_",
+		"P256_XMD:SHA-999_SSWU_RO_",
+		"curve25519_XMD:SHA-512_SSWU_RO_",
+		"edwards25519_XMD:SHA-512_R255MAP_RO_",
+		"ristretto255_XMD:SHA-512_ELL2_RO_",
+		"edwards448_XOF:SHAKE256_D448MAP_RO_",
+		"decaf448_XOF:SHAKE256_R255MAP_RO_",
+	} {
+		if _, err := id.Get(dst); !errors.Is(err, h2c.ErrUnsupportedSuite) {
+			t.Fatalf("suite: %v\ngot:  %v\nwant: %v", id, err, h2c.ErrUnsupportedSuite)
+		}
+	}
+
+	// SHAKE128 does not reach the 192-bit security level of P384.
+	if _, err := h2c.SuiteID("P384_XOF:SHAKE128_SSWU_RO_").Get(dst); !errors.Is(err, h2c.ErrInvalidSecurityLevel) {
+		t.Fatalf("got:  %v\nwant: %v", err, h2c.ErrInvalidSecurityLevel)
+	}
+
+	// Hashing to ristretto255 has no nonuniform encoding.
+	if _, err := h2c.SuiteID("ristretto255_XMD:SHA-512_R255MAP_NU_").Get(dst); !errors.Is(err, h2c.ErrGroupEncoding) {
+		t.Fatalf("got:  %v\nwant: %v", err, h2c.ErrGroupEncoding)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"crypto"
 	_ "crypto/sha256" // To link the sha256 module
 	_ "crypto/sha512" // To link the sha512 module
+	"errors"
 	"fmt"
+	"sync"
+
 	"github.com/armfazh/h2c-go-ref/xof"
 
 	C "github.com/armfazh/h2c-go-ref/curve"
@@ -35,77 +38,152 @@ const (
 	BLS12381G1_XMDSHA256_SSWU_RO_   SuiteID = "BLS12381G1_XMD:SHA-256_SSWU_RO_"
 	BLS12381G2_XMDSHA256_SSWU_NU_   SuiteID = "BLS12381G2_XMD:SHA-256_SSWU_NU_"
 	BLS12381G2_XMDSHA256_SSWU_RO_   SuiteID = "BLS12381G2_XMD:SHA-256_SSWU_RO_"
+
+	Ristretto255_XMDSHA512_R255MAP_RO_ SuiteID = "ristretto255_XMD:SHA-512_R255MAP_RO_"
+	Decaf448_XOFSHAKE256_D448MAP_RO_   SuiteID = "decaf448_XOF:SHAKE256_D448MAP_RO_"
+
+	BN254G1_XMDSHA256_SVDW_NU_ SuiteID = "BN254G1_XMD:SHA-256_SVDW_NU_"
+	BN254G1_XMDSHA256_SVDW_RO_ SuiteID = "BN254G1_XMD:SHA-256_SVDW_RO_"
+	BN254G2_XMDSHA256_SVDW_NU_ SuiteID = "BN254G2_XMD:SHA-256_SVDW_NU_"
+	BN254G2_XMDSHA256_SVDW_RO_ SuiteID = "BN254G2_XMD:SHA-256_SVDW_RO_"
+
+	BLS12377G1_XMDSHA256_SSWU_NU_ SuiteID = "BLS12377G1_XMD:SHA-256_SSWU_NU_"
+	BLS12377G1_XMDSHA256_SSWU_RO_ SuiteID = "BLS12377G1_XMD:SHA-256_SSWU_RO_"
+	BLS12377G2_XMDSHA256_SVDW_NU_ SuiteID = "BLS12377G2_XMD:SHA-256_SVDW_NU_"
+	BLS12377G2_XMDSHA256_SVDW_RO_ SuiteID = "BLS12377G2_XMD:SHA-256_SVDW_RO_"
+
+	Pallas_XMDBLAKE2b_SSWU_NU_ SuiteID = "pallas_XMD:BLAKE2b_SSWU_NU_"
+	Pallas_XMDBLAKE2b_SSWU_RO_ SuiteID = "pallas_XMD:BLAKE2b_SSWU_RO_"
+	Pallas_XMDSHA256_SSWU_NU_  SuiteID = "pallas_XMD:SHA-256_SSWU_NU_"
+	Pallas_XMDSHA256_SSWU_RO_  SuiteID = "pallas_XMD:SHA-256_SSWU_RO_"
+	Vesta_XMDBLAKE2b_SSWU_NU_  SuiteID = "vesta_XMD:BLAKE2b_SSWU_NU_"
+	Vesta_XMDBLAKE2b_SSWU_RO_  SuiteID = "vesta_XMD:BLAKE2b_SSWU_RO_"
+	Vesta_XMDSHA256_SSWU_NU_   SuiteID = "vesta_XMD:SHA-256_SSWU_NU_"
+	Vesta_XMDSHA256_SSWU_RO_   SuiteID = "vesta_XMD:SHA-256_SSWU_RO_"
 )
 
+// ErrUnsupportedSuite is returned when a SuiteID is not registered.
+var ErrUnsupportedSuite = errors.New("suite not supported")
+
 // Get returns a HashToPoint based on the SuiteID, otherwise returns an error
-// if the SuiteID is not supported or invalid.
+// if the SuiteID is not supported or invalid. A SuiteID that is not registered
+// is assembled from its components (see SuiteID.Parse) when all of them are
+// known. Errors raised while constructing the curve, the mapping or the
+// expander are wrapped, so they can be inspected with errors.Is and errors.As.
 func (id SuiteID) Get(dst []byte) (HashToPoint, error) {
-	if s, ok := supportedSuitesID[id]; ok {
-		E := s.E.Get()
-		m := s.Map.Get(E)
-		exp, err := s.Exp.Get(dst, s.K)
-		if err != nil {
-			return nil, err
-		}
-		e := &encoding{
-			E: E,
-			Field: &fieldEncoding{
-				F:   E.Field(),
-				Exp: exp,
-				L:   s.L,
-			},
-			Mapping: m,
-		}
-		if s.RO {
-			return &hashToCurve{e}, nil
+	s, err := id.builder()
+	if err != nil {
+		return nil, err
+	}
+	h, err := s.Get(dst)
+	if err != nil {
+		return nil, fmt.Errorf("Suite: %v: %w", id, err)
+	}
+	return h, nil
+}
+
+// GetHashToScalar returns a HashToScalar for the group of the suite that
+// uses its own domain separation tag, so scalars are domain-separated from
+// points. The security level k determines L; if k is zero, the security
+// level of the suite is used.
+func (id SuiteID) GetHashToScalar(dst []byte, k uint) (HashToScalar, error) {
+	s, err := id.builder()
+	if err != nil {
+		return nil, err
+	}
+	h, err := s.GetHashToScalar(dst, k)
+	if err != nil {
+		return nil, fmt.Errorf("Suite: %v: %w", id, err)
+	}
+	return h, nil
+}
+
+// builder returns the components of a registered suite, or assembles them
+// if the suite is not registered.
+func (id SuiteID) builder() (SuiteBuilder, error) {
+	suitesMu.RLock()
+	s, ok := supportedSuitesID[id]
+	suitesMu.RUnlock()
+	if !ok {
+		var err error
+		if s, err = id.resolve(); err != nil {
+			return s, fmt.Errorf("%w: %v: %v", ErrUnsupportedSuite, id, err)
 		}
-		return &encodeToCurve{e}, nil
 	}
-	return nil, fmt.Errorf("Suite: %v not supported", id)
+	return s, nil
 }
 
-type params struct {
-	ID  SuiteID
-	E   C.ID
-	K   uint
-	Exp ExpanderDesc
-	Map M.MapDescriptor
-	L   uint
-	RO  bool
+// resolve assembles a suite from the components of the identifier.
+func (id SuiteID) resolve() (SuiteBuilder, error) {
+	c, err := id.Parse()
+	if err != nil {
+		return SuiteBuilder{}, err
+	}
+	return c.Builder()
 }
 
-func (id SuiteID) register(s *params) {
-	s.ID = id
-	supportedSuitesID[id] = *s
+func (id SuiteID) register(s *SuiteBuilder) { supportedSuitesID[id] = *s }
+
+// unregister removes a suite registered with SuiteBuilder.Register.
+func (id SuiteID) unregister() {
+	suitesMu.Lock()
+	defer suitesMu.Unlock()
+	delete(supportedSuitesID, id)
 }
 
-var supportedSuitesID map[SuiteID]params
+var (
+	suitesMu          sync.RWMutex
+	supportedSuitesID map[SuiteID]SuiteBuilder
+)
 
 func init() {
-	supportedSuitesID = make(map[SuiteID]params)
+	supportedSuitesID = make(map[SuiteID]SuiteBuilder)
 	sha256 := ExpanderDesc{XMD, uint(crypto.SHA256)}
 	sha384 := ExpanderDesc{XMD, uint(crypto.SHA384)}
 	sha512 := ExpanderDesc{XMD, uint(crypto.SHA512)}
 	shake256 := ExpanderDesc{XOF, uint(xof.SHAKE256)}
+	blake2bZcash := ExpanderDesc{OTHER, XMDBLAKE2bZcash}
 
-	P256_XMDSHA256_SSWU_NU_.register(&params{E: C.P256, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -10}, L: 48, RO: false})
-	P256_XMDSHA256_SSWU_RO_.register(&params{E: C.P256, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -10}, L: 48, RO: true})
-	P384_XMDSHA384_SSWU_NU_.register(&params{E: C.P384, K: 192, Exp: sha384, Map: M.MapDescriptor{ID: M.SSWU, Z: -12}, L: 72, RO: false})
-	P384_XMDSHA384_SSWU_RO_.register(&params{E: C.P384, K: 192, Exp: sha384, Map: M.MapDescriptor{ID: M.SSWU, Z: -12}, L: 72, RO: true})
-	P521_XMDSHA512_SSWU_NU_.register(&params{E: C.P521, K: 256, Exp: sha512, Map: M.MapDescriptor{ID: M.SSWU, Z: -4}, L: 98, RO: false})
-	P521_XMDSHA512_SSWU_RO_.register(&params{E: C.P521, K: 256, Exp: sha512, Map: M.MapDescriptor{ID: M.SSWU, Z: -4}, L: 98, RO: true})
-	Curve25519_XMDSHA512_ELL2_NU_.register(&params{E: C.Curve25519, K: 128, Exp: sha512, Map: M.MapDescriptor{ID: M.ELL2, Z: 2}, L: 48, RO: false})
-	Curve25519_XMDSHA512_ELL2_RO_.register(&params{E: C.Curve25519, K: 128, Exp: sha512, Map: M.MapDescriptor{ID: M.ELL2, Z: 2}, L: 48, RO: true})
-	Edwards25519_XMDSHA512_ELL2_NU_.register(&params{E: C.Edwards25519, K: 128, Exp: sha512, Map: M.MapDescriptor{ID: M.ELL2, Z: 2}, L: 48, RO: false})
-	Edwards25519_XMDSHA512_ELL2_RO_.register(&params{E: C.Edwards25519, K: 128, Exp: sha512, Map: M.MapDescriptor{ID: M.ELL2, Z: 2}, L: 48, RO: true})
-	Curve448_XOFSHAKE256_ELL2_NU_.register(&params{E: C.Curve448, K: 224, Exp: shake256, Map: M.MapDescriptor{ID: M.ELL2, Z: -1}, L: 84, RO: false})
-	Curve448_XOFSHAKE256_ELL2_RO_.register(&params{E: C.Curve448, K: 224, Exp: shake256, Map: M.MapDescriptor{ID: M.ELL2, Z: -1}, L: 84, RO: true})
-	Edwards448_XOFSHAKE256_ELL2_NU_.register(&params{E: C.Edwards448, K: 224, Exp: shake256, Map: M.MapDescriptor{ID: M.ELL2, Z: -1}, L: 84, RO: false})
-	Edwards448_XOFSHAKE256_ELL2_RO_.register(&params{E: C.Edwards448, K: 224, Exp: shake256, Map: M.MapDescriptor{ID: M.ELL2, Z: -1}, L: 84, RO: true})
-	Secp256k1_XMDSHA256_SSWU_NU_.register(&params{E: C.SECP256K1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -11, Iso: C.GetSECP256K1Isogeny}, L: 48, RO: false})
-	Secp256k1_XMDSHA256_SSWU_RO_.register(&params{E: C.SECP256K1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -11, Iso: C.GetSECP256K1Isogeny}, L: 48, RO: true})
-	BLS12381G1_XMDSHA256_SSWU_NU_.register(&params{E: C.BLS12381G1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: 11, Iso: C.GetBLS12381G1Isogeny}, L: 64, RO: false})
-	BLS12381G1_XMDSHA256_SSWU_RO_.register(&params{E: C.BLS12381G1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: 11, Iso: C.GetBLS12381G1Isogeny}, L: 64, RO: true})
-	BLS12381G2_XMDSHA256_SSWU_NU_.register(&params{E: C.BLS12381G2, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: []interface{}{-2, -1}, Iso: C.GetBLS12381G2Isogeny}, L: 64, RO: false})
-	BLS12381G2_XMDSHA256_SSWU_RO_.register(&params{E: C.BLS12381G2, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: []interface{}{-2, -1}, Iso: C.GetBLS12381G2Isogeny}, L: 64, RO: true})
+	P256_XMDSHA256_SSWU_NU_.register(&SuiteBuilder{E: C.P256, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -10}, L: 48, RO: false})
+	P256_XMDSHA256_SSWU_RO_.register(&SuiteBuilder{E: C.P256, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -10}, L: 48, RO: true})
+	P384_XMDSHA384_SSWU_NU_.register(&SuiteBuilder{E: C.P384, K: 192, Exp: sha384, Map: M.MapDescriptor{ID: M.SSWU, Z: -12}, L: 72, RO: false})
+	P384_XMDSHA384_SSWU_RO_.register(&SuiteBuilder{E: C.P384, K: 192, Exp: sha384, Map: M.MapDescriptor{ID: M.SSWU, Z: -12}, L: 72, RO: true})
+	P521_XMDSHA512_SSWU_NU_.register(&SuiteBuilder{E: C.P521, K: 256, Exp: sha512, Map: M.MapDescriptor{ID: M.SSWU, Z: -4}, L: 98, RO: false})
+	P521_XMDSHA512_SSWU_RO_.register(&SuiteBuilder{E: C.P521, K: 256, Exp: sha512, Map: M.MapDescriptor{ID: M.SSWU, Z: -4}, L: 98, RO: true})
+	Curve25519_XMDSHA512_ELL2_NU_.register(&SuiteBuilder{E: C.Curve25519, K: 128, Exp: sha512, Map: M.MapDescriptor{ID: M.ELL2, Z: 2}, L: 48, RO: false})
+	Curve25519_XMDSHA512_ELL2_RO_.register(&SuiteBuilder{E: C.Curve25519, K: 128, Exp: sha512, Map: M.MapDescriptor{ID: M.ELL2, Z: 2}, L: 48, RO: true})
+	Edwards25519_XMDSHA512_ELL2_NU_.register(&SuiteBuilder{E: C.Edwards25519, K: 128, Exp: sha512, Map: M.MapDescriptor{ID: M.ELL2, Z: 2}, L: 48, RO: false})
+	Edwards25519_XMDSHA512_ELL2_RO_.register(&SuiteBuilder{E: C.Edwards25519, K: 128, Exp: sha512, Map: M.MapDescriptor{ID: M.ELL2, Z: 2}, L: 48, RO: true})
+	Curve448_XOFSHAKE256_ELL2_NU_.register(&SuiteBuilder{E: C.Curve448, K: 224, Exp: shake256, Map: M.MapDescriptor{ID: M.ELL2, Z: -1}, L: 84, RO: false})
+	Curve448_XOFSHAKE256_ELL2_RO_.register(&SuiteBuilder{E: C.Curve448, K: 224, Exp: shake256, Map: M.MapDescriptor{ID: M.ELL2, Z: -1}, L: 84, RO: true})
+	Edwards448_XOFSHAKE256_ELL2_NU_.register(&SuiteBuilder{E: C.Edwards448, K: 224, Exp: shake256, Map: M.MapDescriptor{ID: M.ELL2, Z: -1}, L: 84, RO: false})
+	Edwards448_XOFSHAKE256_ELL2_RO_.register(&SuiteBuilder{E: C.Edwards448, K: 224, Exp: shake256, Map: M.MapDescriptor{ID: M.ELL2, Z: -1}, L: 84, RO: true})
+	Secp256k1_XMDSHA256_SSWU_NU_.register(&SuiteBuilder{E: C.SECP256K1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -11, Iso: C.GetSECP256K1Isogeny}, L: 48, RO: false})
+	Secp256k1_XMDSHA256_SSWU_RO_.register(&SuiteBuilder{E: C.SECP256K1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -11, Iso: C.GetSECP256K1Isogeny}, L: 48, RO: true})
+	BLS12381G1_XMDSHA256_SSWU_NU_.register(&SuiteBuilder{E: C.BLS12381G1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: 11, Iso: C.GetBLS12381G1Isogeny}, L: 64, RO: false})
+	BLS12381G1_XMDSHA256_SSWU_RO_.register(&SuiteBuilder{E: C.BLS12381G1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: 11, Iso: C.GetBLS12381G1Isogeny}, L: 64, RO: true})
+	BLS12381G2_XMDSHA256_SSWU_NU_.register(&SuiteBuilder{E: C.BLS12381G2, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: []interface{}{-2, -1}, Iso: C.GetBLS12381G2Isogeny}, L: 64, RO: false})
+	BLS12381G2_XMDSHA256_SSWU_RO_.register(&SuiteBuilder{E: C.BLS12381G2, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: []interface{}{-2, -1}, Iso: C.GetBLS12381G2Isogeny}, L: 64, RO: true})
+	Ristretto255_XMDSHA512_R255MAP_RO_.register(&SuiteBuilder{E: C.Edwards25519, K: 128, Exp: sha512, Map: M.MapDescriptor{ID: M.R255MAP}, L: 32, RO: true})
+	Decaf448_XOFSHAKE256_D448MAP_RO_.register(&SuiteBuilder{E: C.Edwards448, K: 224, Exp: shake256, Map: M.MapDescriptor{ID: M.D448MAP}, L: 56, RO: true})
+	BN254G1_XMDSHA256_SVDW_NU_.register(&SuiteBuilder{E: C.BN254G1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SVDW}, L: 48, RO: false})
+	BN254G1_XMDSHA256_SVDW_RO_.register(&SuiteBuilder{E: C.BN254G1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SVDW}, L: 48, RO: true})
+	BN254G2_XMDSHA256_SVDW_NU_.register(&SuiteBuilder{E: C.BN254G2, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SVDW}, L: 48, RO: false, Clear: C.GetBN254G2CofactorClearing})
+	BN254G2_XMDSHA256_SVDW_RO_.register(&SuiteBuilder{E: C.BN254G2, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SVDW}, L: 48, RO: true, Clear: C.GetBN254G2CofactorClearing})
+	// The BLS12377G1 suites use the 2-isogeny of gnark-crypto, but gnark-crypto
+	// takes Z = 5, which fails criteria 3 and 4 of Appendix H.2 of RFC 9380.
+	// These suites take Z = -11 as given by find_z_sswu; hence, their outputs
+	// never match those of HashToG1 and EncodeToG1 of gnark-crypto.
+	BLS12377G1_XMDSHA256_SSWU_NU_.register(&SuiteBuilder{E: C.BLS12377G1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -11, Iso: C.GetBLS12377G1Isogeny}, L: 64, RO: false, Clear: C.GetBLS12377G1CofactorClearing})
+	BLS12377G1_XMDSHA256_SSWU_RO_.register(&SuiteBuilder{E: C.BLS12377G1, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -11, Iso: C.GetBLS12377G1Isogeny}, L: 64, RO: true, Clear: C.GetBLS12377G1CofactorClearing})
+	BLS12377G2_XMDSHA256_SVDW_NU_.register(&SuiteBuilder{E: C.BLS12377G2, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SVDW}, L: 64, RO: false, Clear: C.GetBLS12377G2CofactorClearing})
+	BLS12377G2_XMDSHA256_SVDW_RO_.register(&SuiteBuilder{E: C.BLS12377G2, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SVDW}, L: 64, RO: true, Clear: C.GetBLS12377G2CofactorClearing})
+	Pallas_XMDBLAKE2b_SSWU_NU_.register(&SuiteBuilder{E: C.Pallas, K: 128, Exp: blake2bZcash, Map: M.MapDescriptor{ID: M.SSWU, Z: -13, Iso: C.GetPallasIsogeny}, L: 64, RO: false})
+	Pallas_XMDBLAKE2b_SSWU_RO_.register(&SuiteBuilder{E: C.Pallas, K: 128, Exp: blake2bZcash, Map: M.MapDescriptor{ID: M.SSWU, Z: -13, Iso: C.GetPallasIsogeny}, L: 64, RO: true})
+	Pallas_XMDSHA256_SSWU_NU_.register(&SuiteBuilder{E: C.Pallas, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -13, Iso: C.GetPallasIsogeny}, L: 48, RO: false})
+	Pallas_XMDSHA256_SSWU_RO_.register(&SuiteBuilder{E: C.Pallas, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -13, Iso: C.GetPallasIsogeny}, L: 48, RO: true})
+	Vesta_XMDBLAKE2b_SSWU_NU_.register(&SuiteBuilder{E: C.Vesta, K: 128, Exp: blake2bZcash, Map: M.MapDescriptor{ID: M.SSWU, Z: -13, Iso: C.GetVestaIsogeny}, L: 64, RO: false})
+	Vesta_XMDBLAKE2b_SSWU_RO_.register(&SuiteBuilder{E: C.Vesta, K: 128, Exp: blake2bZcash, Map: M.MapDescriptor{ID: M.SSWU, Z: -13, Iso: C.GetVestaIsogeny}, L: 64, RO: true})
+	Vesta_XMDSHA256_SSWU_NU_.register(&SuiteBuilder{E: C.Vesta, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -13, Iso: C.GetVestaIsogeny}, L: 48, RO: false})
+	Vesta_XMDSHA256_SSWU_RO_.register(&SuiteBuilder{E: C.Vesta, K: 128, Exp: sha256, Map: M.MapDescriptor{ID: M.SSWU, Z: -13, Iso: C.GetVestaIsogeny}, L: 48, RO: true})
 }
@@ -0,0 +1,146 @@
+package xof
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// This file implements Ascon-XOF128 and Ascon-CXOF128 as specified in NIST
+// SP 800-232.
+
+const (
+	asconRate = 8
+
+	asconXOF128IV  = 0x0000080000cc0003
+	asconCXOF128IV = 0x0000080000cc0004
+
+	// AsconCXOF128MaxCustomization is the maximum length in bytes of the
+	// customization string of Ascon-CXOF128.
+	AsconCXOF128MaxCustomization = 256
+)
+
+// asconP applies the permutation Ascon-p[12] to s.
+func asconP(s *[5]uint64) {
+	x0, x1, x2, x3, x4 := s[0], s[1], s[2], s[3], s[4]
+	for c := uint64(0xf0); c >= 0x4b; c -= 0x0f {
+		x2 ^= c
+
+		x0 ^= x4
+		x4 ^= x3
+		x2 ^= x1
+		t0 := ^x0 & x1
+		t1 := ^x1 & x2
+		t2 := ^x2 & x3
+		t3 := ^x3 & x4
+		t4 := ^x4 & x0
+		x0 ^= t1
+		x1 ^= t2
+		x2 ^= t3
+		x3 ^= t4
+		x4 ^= t0
+		x1 ^= x0
+		x0 ^= x4
+		x3 ^= x2
+		x2 = ^x2
+
+		x0 ^= bits.RotateLeft64(x0, -19) ^ bits.RotateLeft64(x0, -28)
+		x1 ^= bits.RotateLeft64(x1, -61) ^ bits.RotateLeft64(x1, -39)
+		x2 ^= bits.RotateLeft64(x2, -1) ^ bits.RotateLeft64(x2, -6)
+		x3 ^= bits.RotateLeft64(x3, -10) ^ bits.RotateLeft64(x3, -17)
+		x4 ^= bits.RotateLeft64(x4, -7) ^ bits.RotateLeft64(x4, -41)
+	}
+	s[0], s[1], s[2], s[3], s[4] = x0, x1, x2, x3, x4
+}
+
+// ascon is the sponge of Ascon-XOF128 and Ascon-CXOF128.
+type ascon struct {
+	init      [5]uint64 // init is the state after absorbing the customization.
+	s         [5]uint64
+	buf       [asconRate]byte
+	n         int // n is the number of bytes in buf.
+	squeezing bool
+}
+
+// NewAsconXOF128 returns Ascon-XOF128.
+func NewAsconXOF128() XOF {
+	a := &ascon{}
+	a.init[0] = asconXOF128IV
+	asconP(&a.init)
+	a.Reset()
+	return a
+}
+
+// NewAsconCXOF128 returns Ascon-CXOF128 with customization string Z. It
+// panics if Z is longer than AsconCXOF128MaxCustomization bytes.
+func NewAsconCXOF128(Z []byte) XOF {
+	if len(Z) > AsconCXOF128MaxCustomization {
+		panic("xof: customization string too long")
+	}
+	a := &ascon{}
+	a.s[0] = asconCXOF128IV
+	asconP(&a.s)
+	a.s[0] ^= 8 * uint64(len(Z))
+	asconP(&a.s)
+	_, _ = a.Write(Z)
+	a.pad()
+	a.init = a.s
+	a.Reset()
+	return a
+}
+
+func (a *ascon) Write(p []byte) (int, error) {
+	if a.squeezing {
+		panic("xof: Write after Read")
+	}
+	n := len(p)
+	for len(p) > 0 {
+		k := copy(a.buf[a.n:], p)
+		a.n += k
+		p = p[k:]
+		if a.n == asconRate {
+			a.s[0] ^= binary.LittleEndian.Uint64(a.buf[:])
+			asconP(&a.s)
+			a.n = 0
+		}
+	}
+	return n, nil
+}
+
+// pad absorbs the last, padded, block of the input.
+func (a *ascon) pad() {
+	for i := a.n; i < asconRate; i++ {
+		a.buf[i] = 0
+	}
+	a.buf[a.n] = 0x01
+	a.s[0] ^= binary.LittleEndian.Uint64(a.buf[:])
+	asconP(&a.s)
+	a.n = 0
+}
+
+func (a *ascon) Read(p []byte) (int, error) {
+	if !a.squeezing {
+		a.pad()
+		a.squeezing = true
+		binary.LittleEndian.PutUint64(a.buf[:], a.s[0])
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if a.n == asconRate {
+			asconP(&a.s)
+			binary.LittleEndian.PutUint64(a.buf[:], a.s[0])
+			a.n = 0
+		}
+		k := copy(p, a.buf[a.n:])
+		a.n += k
+		p = p[k:]
+	}
+	return n, nil
+}
+
+func (a *ascon) Clone() XOF { c := *a; return &c }
+
+func (a *ascon) Reset() {
+	a.s = a.init
+	a.n = 0
+	a.squeezing = false
+}
@@ -0,0 +1,88 @@
+package xof_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/armfazh/h2c-go-ref/xof"
+)
+
+// readKAT parses a file of known-answer tests in the format used by the
+// NIST lightweight cryptography project.
+func readKAT(t *testing.T, name string) []map[string][]byte {
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var kats []map[string][]byte
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			t.Fatalf("invalid line: %q", line)
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		if key == "Count" {
+			kats = append(kats, make(map[string][]byte))
+			continue
+		}
+		b, err := hex.DecodeString(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kats[len(kats)-1][key] = b
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return kats
+}
+
+func TestAscon(t *testing.T) {
+	for _, v := range []struct {
+		file string
+		new  func(Z []byte) xof.XOF
+	}{
+		{"testdata/ascon_xof128.txt", func([]byte) xof.XOF { return xof.NewAsconXOF128() }},
+		{"testdata/ascon_cxof128.txt", xof.NewAsconCXOF128},
+	} {
+		for i, kat := range readKAT(t, v.file) {
+			x := v.new(kat["Z"])
+			for _, c := range kat["Msg"] {
+				_, _ = x.Write([]byte{c})
+			}
+			if got := read(x.Clone(), len(kat["MD"])); !bytes.Equal(got, kat["MD"]) {
+				t.Fatalf("%v count: %v\ngot:  %X\nwant: %X", v.file, i+1, got, kat["MD"])
+			}
+			x.Reset()
+			_, _ = x.Write(kat["Msg"])
+			if got := read(x, len(kat["MD"])); !bytes.Equal(got, kat["MD"]) {
+				t.Fatalf("%v count: %v\ngot:  %X\nwant: %X", v.file, i+1, got, kat["MD"])
+			}
+		}
+	}
+}
+
+func TestAsconCustomization(t *testing.T) {
+	x := xof.NewAsconCXOF128([]byte("a"))
+	y := xof.NewAsconCXOF128([]byte("b"))
+	z := xof.NewAsconXOF128()
+	if a, b, c := read(x, 32), read(y, 32), read(z, 32); bytes.Equal(a, b) || bytes.Equal(a, c) {
+		t.Fatal("customization strings are not domain separated")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	xof.NewAsconCXOF128(make([]byte, xof.AsconCXOF128MaxCustomization+1))
+}
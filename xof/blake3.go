@@ -0,0 +1,266 @@
+package xof
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// This file implements the BLAKE3 hash function in its three modes: hash,
+// keyed hash and key derivation. It follows the reference implementation of
+// the BLAKE3 specification.
+
+const (
+	blake3BlockLen = 64
+	blake3ChunkLen = 1024
+
+	blake3ChunkStart        = 1 << 0
+	blake3ChunkEnd          = 1 << 1
+	blake3Parent            = 1 << 2
+	blake3Root              = 1 << 3
+	blake3KeyedHash         = 1 << 4
+	blake3DeriveKeyContext  = 1 << 5
+	blake3DeriveKeyMaterial = 1 << 6
+)
+
+var blake3IV = [8]uint32{
+	0x6A09E667, 0xBB67AE85, 0x3C6EF372, 0xA54FF53A,
+	0x510E527F, 0x9B05688C, 0x1F83D9AB, 0x5BE0CD19,
+}
+
+var blake3Perm = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+func blake3G(s *[16]uint32, a, b, c, d int, x, y uint32) {
+	s[a] += s[b] + x
+	s[d] = bits.RotateLeft32(s[d]^s[a], -16)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -12)
+	s[a] += s[b] + y
+	s[d] = bits.RotateLeft32(s[d]^s[a], -8)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -7)
+}
+
+// blake3Compress returns the full 64-byte state of the compression function.
+func blake3Compress(cv *[8]uint32, m *[16]uint32, counter uint64, blockLen, flags uint32) [16]uint32 {
+	s := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3], cv[4], cv[5], cv[6], cv[7],
+		blake3IV[0], blake3IV[1], blake3IV[2], blake3IV[3],
+		uint32(counter), uint32(counter >> 32), blockLen, flags,
+	}
+	w := *m
+	for r := 0; r < 7; r++ {
+		blake3G(&s, 0, 4, 8, 12, w[0], w[1])
+		blake3G(&s, 1, 5, 9, 13, w[2], w[3])
+		blake3G(&s, 2, 6, 10, 14, w[4], w[5])
+		blake3G(&s, 3, 7, 11, 15, w[6], w[7])
+		blake3G(&s, 0, 5, 10, 15, w[8], w[9])
+		blake3G(&s, 1, 6, 11, 12, w[10], w[11])
+		blake3G(&s, 2, 7, 8, 13, w[12], w[13])
+		blake3G(&s, 3, 4, 9, 14, w[14], w[15])
+		var p [16]uint32
+		for i := range p {
+			p[i] = w[blake3Perm[i]]
+		}
+		w = p
+	}
+	for i := 0; i < 8; i++ {
+		s[i] ^= s[i+8]
+		s[i+8] ^= cv[i]
+	}
+	return s
+}
+
+func blake3Words(b []byte) (m [16]uint32) {
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint32(b[4*i:])
+	}
+	return
+}
+
+// blake3Output is the input of a compression that has not been performed
+// yet; it either yields a chaining value or, at the root, the output stream.
+type blake3Output struct {
+	cv       [8]uint32
+	block    [16]uint32
+	counter  uint64
+	blockLen uint32
+	flags    uint32
+}
+
+func (o *blake3Output) chainingValue() (cv [8]uint32) {
+	s := blake3Compress(&o.cv, &o.block, o.counter, o.blockLen, o.flags)
+	copy(cv[:], s[:8])
+	return
+}
+
+// rootBlock returns the 64-byte output block with index i.
+func (o *blake3Output) rootBlock(i uint64, b *[blake3BlockLen]byte) {
+	s := blake3Compress(&o.cv, &o.block, i, o.blockLen, o.flags|blake3Root)
+	for j := range s {
+		binary.LittleEndian.PutUint32(b[4*j:], s[j])
+	}
+}
+
+type blake3Chunk struct {
+	cv      [8]uint32
+	counter uint64
+	block   [blake3BlockLen]byte
+	n       int // n is the number of bytes in block.
+	blocks  int // blocks is the number of compressed blocks.
+	flags   uint32
+}
+
+func (c *blake3Chunk) len() int { return blake3BlockLen*c.blocks + c.n }
+
+func (c *blake3Chunk) startFlag() uint32 {
+	if c.blocks == 0 {
+		return blake3ChunkStart
+	}
+	return 0
+}
+
+func (c *blake3Chunk) write(p []byte) {
+	for len(p) > 0 {
+		if c.n == blake3BlockLen {
+			m := blake3Words(c.block[:])
+			s := blake3Compress(&c.cv, &m, c.counter, blake3BlockLen, c.flags|c.startFlag())
+			copy(c.cv[:], s[:8])
+			c.blocks++
+			c.block = [blake3BlockLen]byte{}
+			c.n = 0
+		}
+		k := copy(c.block[c.n:], p)
+		c.n += k
+		p = p[k:]
+	}
+}
+
+func (c *blake3Chunk) output() blake3Output {
+	return blake3Output{
+		cv:       c.cv,
+		block:    blake3Words(c.block[:]),
+		counter:  c.counter,
+		blockLen: uint32(c.n),
+		flags:    c.flags | c.startFlag() | blake3ChunkEnd,
+	}
+}
+
+func blake3ParentOutput(left, right [8]uint32, key *[8]uint32, flags uint32) blake3Output {
+	o := blake3Output{cv: *key, blockLen: blake3BlockLen, flags: flags | blake3Parent}
+	copy(o.block[:8], left[:])
+	copy(o.block[8:], right[:])
+	return o
+}
+
+// BLAKE3 is the extendable-output function of BLAKE3.
+type BLAKE3 struct {
+	key   [8]uint32
+	flags uint32
+	chunk blake3Chunk
+	stack [][8]uint32 // stack holds the chaining values of complete subtrees.
+
+	squeezing bool
+	out       blake3Output
+	buf       [blake3BlockLen]byte
+	offset    uint64 // offset is the number of bytes already read.
+}
+
+// NewBLAKE3 returns BLAKE3 in hash mode.
+func NewBLAKE3() *BLAKE3 { return newBLAKE3(blake3IV, 0) }
+
+// NewBLAKE3Keyed returns BLAKE3 in keyed hash mode.
+func NewBLAKE3Keyed(key *[32]byte) *BLAKE3 { return newBLAKE3(blake3Key(key), blake3KeyedHash) }
+
+// NewBLAKE3DeriveKey returns BLAKE3 in key derivation mode; the input is the
+// key material and context is a hardcoded, globally unique string.
+func NewBLAKE3DeriveKey(context string) *BLAKE3 {
+	h := newBLAKE3(blake3IV, blake3DeriveKeyContext)
+	_, _ = h.Write([]byte(context))
+	var key [32]byte
+	_, _ = h.Read(key[:])
+	return newBLAKE3(blake3Key(&key), blake3DeriveKeyMaterial)
+}
+
+func blake3Key(key *[32]byte) (k [8]uint32) {
+	for i := range k {
+		k[i] = binary.LittleEndian.Uint32(key[4*i:])
+	}
+	return
+}
+
+func newBLAKE3(key [8]uint32, flags uint32) *BLAKE3 {
+	h := &BLAKE3{key: key, flags: flags}
+	h.Reset()
+	return h
+}
+
+// Write absorbs more data; it panics if called after Read.
+func (h *BLAKE3) Write(p []byte) (int, error) {
+	if h.squeezing {
+		panic("xof: Write after Read")
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if h.chunk.len() == blake3ChunkLen {
+			o := h.chunk.output()
+			h.pushChunk(o.chainingValue(), h.chunk.counter+1)
+			h.chunk = blake3Chunk{cv: h.key, counter: h.chunk.counter + 1, flags: h.flags}
+		}
+		k := blake3ChunkLen - h.chunk.len()
+		if k > len(p) {
+			k = len(p)
+		}
+		h.chunk.write(p[:k])
+		p = p[k:]
+	}
+	return n, nil
+}
+
+// pushChunk adds the chaining value of a chunk, merging the subtrees that
+// are complete once total chunks have been processed.
+func (h *BLAKE3) pushChunk(cv [8]uint32, total uint64) {
+	for ; total&1 == 0; total >>= 1 {
+		left := h.stack[len(h.stack)-1]
+		h.stack = h.stack[:len(h.stack)-1]
+		o := blake3ParentOutput(left, cv, &h.key, h.flags)
+		cv = o.chainingValue()
+	}
+	h.stack = append(h.stack, cv)
+}
+
+// Read squeezes output; no more data can be written after the first call.
+func (h *BLAKE3) Read(p []byte) (int, error) {
+	if !h.squeezing {
+		h.out = h.chunk.output()
+		for i := len(h.stack) - 1; i >= 0; i-- {
+			h.out = blake3ParentOutput(h.stack[i], h.out.chainingValue(), &h.key, h.flags)
+		}
+		h.squeezing = true
+	}
+	n := len(p)
+	for len(p) > 0 {
+		i := h.offset % blake3BlockLen
+		if i == 0 {
+			h.out.rootBlock(h.offset/blake3BlockLen, &h.buf)
+		}
+		k := copy(p, h.buf[i:])
+		h.offset += uint64(k)
+		p = p[k:]
+	}
+	return n, nil
+}
+
+// Clone returns a copy of the BLAKE3 in its current state.
+func (h *BLAKE3) Clone() XOF {
+	c := *h
+	c.stack = append([][8]uint32{}, h.stack...)
+	return &c
+}
+
+// Reset resets the BLAKE3 to its initial state, keeping its mode and key.
+func (h *BLAKE3) Reset() {
+	h.chunk = blake3Chunk{cv: h.key, flags: h.flags}
+	h.stack = h.stack[:0]
+	h.squeezing = false
+	h.offset = 0
+}
@@ -0,0 +1,72 @@
+package xof_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/armfazh/h2c-go-ref/xof"
+)
+
+type blake3Vectors struct {
+	Key     string `json:"key"`
+	Context string `json:"context_string"`
+	Cases   []struct {
+		InputLen  int    `json:"input_len"`
+		Hash      string `json:"hash"`
+		KeyedHash string `json:"keyed_hash"`
+		DeriveKey string `json:"derive_key"`
+	} `json:"cases"`
+}
+
+func TestBLAKE3(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/blake3.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v blake3Vectors
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	var key [32]byte
+	copy(key[:], v.Key)
+	for _, c := range v.Cases {
+		in := make([]byte, c.InputLen)
+		for i := range in {
+			in[i] = byte(i % 251)
+		}
+		for _, m := range []struct {
+			name string
+			x    xof.XOF
+			want string
+		}{
+			{"hash", xof.NewBLAKE3(), c.Hash},
+			{"keyed_hash", xof.NewBLAKE3Keyed(&key), c.KeyedHash},
+			{"derive_key", xof.NewBLAKE3DeriveKey(v.Context), c.DeriveKey},
+		} {
+			want, err := hex.DecodeString(m.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Absorb in uneven pieces and squeeze in two reads to cross
+			// block and chunk boundaries.
+			for i := 0; i < len(in); i += 1000 {
+				end := i + 1000
+				if end > len(in) {
+					end = len(in)
+				}
+				_, _ = m.x.Write(in[i:end])
+			}
+			y := m.x.Clone()
+			if got := read(m.x, len(want)); !bytes.Equal(got, want) {
+				t.Fatalf("%v input_len: %v\ngot:  %x\nwant: %x", m.name, c.InputLen, got, want)
+			}
+			got := append(read(y, 33), read(y, len(want)-33)...)
+			if !bytes.Equal(got, want) {
+				t.Fatalf("%v input_len: %v\ngot:  %x\nwant: %x", m.name, c.InputLen, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package xof
+
+// This file implements TurboSHAKE and KangarooTwelve as specified in RFC 9861.
+
+// NewTurboSHAKE128 returns TurboSHAKE128 with domain separation byte D, which
+// must be in the range [0x01, 0x7F]; the default value is 0x1F.
+func NewTurboSHAKE128(D byte) XOF { return newTurboSHAKE(168, D) }
+
+// NewTurboSHAKE256 returns TurboSHAKE256 with domain separation byte D, which
+// must be in the range [0x01, 0x7F]; the default value is 0x1F.
+func NewTurboSHAKE256(D byte) XOF { return newTurboSHAKE(136, D) }
+
+func newTurboSHAKE(rate int, D byte) *sponge {
+	if D < 0x01 || D > 0x7F {
+		panic("xof: invalid domain separation byte")
+	}
+	return &sponge{rate: rate, rounds: 12, ds: D}
+}
+
+const k12ChunkSize = 8192
+
+// kangarooTwelve is KT128 or KT256. The first chunk is buffered until either
+// the input ends or a second chunk starts; then, the remaining chunks are
+// hashed as leaves whose chaining values are absorbed by the final node.
+type kangarooTwelve struct {
+	c     []byte // c is the customization string.
+	rate  int
+	cvLen int
+	final *sponge
+	leaf  *sponge
+	chunk []byte // chunk holds the first chunk, or the length of the current leaf.
+	n     uint64 // n is the number of leaves.
+	out   *sponge
+}
+
+// NewKT128 returns KT128 with customization string C.
+func NewKT128(C []byte) XOF { return newKangarooTwelve(168, 32, C) }
+
+// NewKT256 returns KT256 with customization string C.
+func NewKT256(C []byte) XOF { return newKangarooTwelve(136, 64, C) }
+
+func newKangarooTwelve(rate, cvLen int, C []byte) *kangarooTwelve {
+	return &kangarooTwelve{c: append([]byte{}, C...), rate: rate, cvLen: cvLen}
+}
+
+func (k *kangarooTwelve) Write(p []byte) (int, error) {
+	if k.out != nil {
+		panic("xof: Write after Read")
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if k.final == nil {
+			m := copy(k.chunk[len(k.chunk):cap(k.chunk)], p)
+			if cap(k.chunk) == 0 {
+				k.chunk = make([]byte, 0, k12ChunkSize)
+				continue
+			}
+			k.chunk = k.chunk[:len(k.chunk)+m]
+			p = p[m:]
+			if len(k.chunk) == k12ChunkSize && len(p) > 0 {
+				k.final = newTurboSHAKE(k.rate, 0x06)
+				_, _ = k.final.Write(k.chunk)
+				_, _ = k.final.Write([]byte{0x03, 0, 0, 0, 0, 0, 0, 0})
+				k.chunk = k.chunk[:0]
+			}
+			continue
+		}
+		if k.leaf == nil {
+			k.leaf = newTurboSHAKE(k.rate, 0x0B)
+		}
+		m := k12ChunkSize - len(k.chunk)
+		if m > len(p) {
+			m = len(p)
+		}
+		_, _ = k.leaf.Write(p[:m])
+		k.chunk = k.chunk[:len(k.chunk)+m]
+		p = p[m:]
+		if len(k.chunk) == k12ChunkSize {
+			k.endLeaf()
+		}
+	}
+	return n, nil
+}
+
+// endLeaf absorbs the chaining value of the current leaf into the final node.
+func (k *kangarooTwelve) endLeaf() {
+	cv := make([]byte, k.cvLen)
+	_, _ = k.leaf.Read(cv)
+	_, _ = k.final.Write(cv)
+	k.leaf = nil
+	k.chunk = k.chunk[:0]
+	k.n++
+}
+
+func (k *kangarooTwelve) Read(p []byte) (int, error) {
+	if k.out == nil {
+		_, _ = k.Write(k.c)
+		_, _ = k.Write(lengthEncode(uint64(len(k.c))))
+		if k.final == nil {
+			k.out = newTurboSHAKE(k.rate, 0x07)
+			_, _ = k.out.Write(k.chunk)
+		} else {
+			if k.leaf != nil {
+				k.endLeaf()
+			}
+			_, _ = k.final.Write(lengthEncode(k.n))
+			_, _ = k.final.Write([]byte{0xFF, 0xFF})
+			k.out = k.final
+		}
+	}
+	return k.out.Read(p)
+}
+
+func (k *kangarooTwelve) Clone() XOF {
+	c := *k
+	c.chunk = append(make([]byte, 0, cap(k.chunk)), k.chunk...)
+	if k.final != nil {
+		c.final = k.final.Clone().(*sponge)
+	}
+	if k.leaf != nil {
+		c.leaf = k.leaf.Clone().(*sponge)
+	}
+	if k.out != nil {
+		if k.out == k.final {
+			c.out = c.final
+		} else {
+			c.out = k.out.Clone().(*sponge)
+		}
+	}
+	return &c
+}
+
+func (k *kangarooTwelve) Reset() {
+	k.final, k.leaf, k.out = nil, nil, nil
+	k.chunk = k.chunk[:0]
+	k.n = 0
+}
+
+// lengthEncode encodes x as in Section 3.3 of RFC 9861.
+func lengthEncode(x uint64) []byte {
+	var b []byte
+	for ; x > 0; x >>= 8 {
+		b = append([]byte{byte(x)}, b...)
+	}
+	return append(b, byte(len(b)))
+}
@@ -0,0 +1,168 @@
+package xof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// ptn returns the pattern of n bytes used by the test vectors of RFC 9861.
+func ptn(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i % 251)
+	}
+	return b
+}
+
+func ff(n int) []byte { return bytes.Repeat([]byte{0xFF}, n) }
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(strings.Replace(s, " ", "", -1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// With 24 rounds, the sponge computes SHAKE128.
+func TestKeccakP1600(t *testing.T) {
+	for _, n := range []int{0, 1, 167, 168, 169, 500} {
+		s := &sponge{rate: 168, rounds: 24, ds: 0x1F}
+		_, _ = s.Write(ptn(n))
+		got := make([]byte, 400)
+		_, _ = s.Read(got)
+		want := make([]byte, 400)
+		sha3.ShakeSum128(want, ptn(n))
+		if !bytes.Equal(got, want) {
+			t.Fatalf("len: %v\ngot:  %x\nwant: %x", n, got, want)
+		}
+	}
+}
+
+// Test vectors from Section 5 of RFC 9861.
+func TestTurboSHAKE(t *testing.T) {
+	for _, v := range []struct {
+		x    XOF
+		in   []byte
+		want string
+	}{
+		{NewTurboSHAKE128(0x1F), nil, "1E 41 5F 1C 59 83 AF F2 16 92 17 27 7D 17 BB 53 8C D9 45 A3 97 DD EC 54 1F 1C E4 1A F2 C1 B7 4C"},
+		{NewTurboSHAKE128(0x1F), ptn(1), "55 CE DD 6F 60 AF 7B B2 9A 40 42 AE 83 2E F3 F5 8D B7 29 9F 89 3E BB 92 47 24 7D 85 69 58 DA A9"},
+		{NewTurboSHAKE128(0x1F), ptn(17), "9C 97 D0 36 A3 BA C8 19 DB 70 ED E0 CA 55 4E C6 E4 C2 A1 A4 FF BF D9 EC 26 9C A6 A1 11 16 12 33"},
+		{NewTurboSHAKE128(0x01), ff(3), "BF 32 3F 94 04 94 E8 8E E1 C5 40 FE 66 0B E8 A0 C9 3F 43 D1 5E C0 06 99 84 62 FA 99 4E ED 5D AB"},
+		{NewTurboSHAKE128(0x06), ff(1), "8E C9 C6 64 65 ED 0D 4A 6C 35 D1 35 06 71 8D 68 7A 25 CB 05 C7 4C CA 1E 42 50 1A BD 83 87 4A 67"},
+		{NewTurboSHAKE128(0x07), ff(3), "B6 58 57 60 01 CA D9 B1 E5 F3 99 A9 F7 77 23 BB A0 54 58 04 2D 68 20 6F 72 52 68 2D BA 36 63 ED"},
+		{NewTurboSHAKE128(0x0B), ff(7), "8D EE AA 1A EC 47 CC EE 56 9F 65 9C 21 DF A8 E1 12 DB 3C EE 37 B1 81 78 B2 AC D8 05 B7 99 CC 37"},
+		{NewTurboSHAKE128(0x30), ff(1), "55 31 22 E2 13 5E 36 3C 32 92 BE D2 C6 42 1F A2 32 BA B0 3D AA 07 C7 D6 63 66 03 28 65 06 32 5B"},
+		{NewTurboSHAKE128(0x7F), ff(3), "16 27 4C C6 56 D4 4C EF D4 22 39 5D 0F 90 53 BD A6 D2 8E 12 2A BA 15 C7 65 E5 AD 0E 6E AF 26 F9"},
+		{NewTurboSHAKE256(0x1F), nil, "36 7A 32 9D AF EA 87 1C 78 02 EC 67 F9 05 AE 13 C5 76 95 DC 2C 66 63 C6 10 35 F5 9A 18 F8 E7 DB 11 ED C0 E1 2E 91 EA 60 EB 6B 32 DF 06 DD 7F 00 2F BA FA BB 6E 13 EC 1C C2 0D 99 55 47 60 0D B0"},
+	} {
+		_, _ = v.x.Write(v.in)
+		want := unhex(t, v.want)
+		got := make([]byte, len(want))
+		_, _ = v.x.Read(got)
+		if !bytes.Equal(got, want) {
+			t.Errorf("in: %x\ngot:  %X\nwant: %X", v.in, got, want)
+		}
+	}
+}
+
+// Test vectors from Section 5 of RFC 9861.
+func TestKangarooTwelve(t *testing.T) {
+	for _, v := range []struct {
+		x    XOF
+		m    []byte
+		want string
+	}{
+		{NewKT128(nil), nil, "1A C2 D4 50 FC 3B 42 05 D1 9D A7 BF CA 1B 37 51 3C 08 03 57 7A C7 16 7F 06 FE 2C E1 F0 EF 39 E5"},
+		{NewKT128(nil), ptn(1), "2B DA 92 45 0E 8B 14 7F 8A 7C B6 29 E7 84 A0 58 EF CA 7C F7 D8 21 8E 02 D3 45 DF AA 65 24 4A 1F"},
+		{NewKT128(nil), ptn(17), "6B F7 5F A2 23 91 98 DB 47 72 E3 64 78 F8 E1 9B 0F 37 12 05 F6 A9 A9 3A 27 3F 51 DF 37 12 28 88"},
+		{NewKT128(nil), ptn(17 * 17), "0C 31 5E BC DE DB F6 14 26 DE 7D CF 8F B7 25 D1 E7 46 75 D7 F5 32 7A 50 67 F3 67 B1 08 EC B6 7C"},
+		{NewKT128(nil), ptn(17 * 17 * 17), "CB 55 2E 2E C7 7D 99 10 70 1D 57 8B 45 7D DF 77 2C 12 E3 22 E4 EE 7F E4 17 F9 2C 75 8F 0D 59 D0"},
+		{NewKT128(nil), ptn(17 * 17 * 17 * 17), "87 01 04 5E 22 20 53 45 FF 4D DA 05 55 5C BB 5C 3A F1 A7 71 C2 B8 9B AE F3 7D B4 3D 99 98 B9 FE"},
+		{NewKT128(ptn(1)), nil, "FA B6 58 DB 63 E9 4A 24 61 88 BF 7A F6 9A 13 30 45 F4 6E E9 84 C5 6E 3C 33 28 CA AF 1A A1 A5 83"},
+		{NewKT128(ptn(41)), ff(1), "D8 48 C5 06 8C ED 73 6F 44 62 15 9B 98 67 FD 4C 20 B8 08 AC C3 D5 BC 48 E0 B0 6B A0 A3 76 2E C4"},
+		{NewKT128(ptn(41 * 41)), ff(3), "C3 89 E5 00 9A E5 71 20 85 4C 2E 8C 64 67 0A C0 13 58 CF 4C 1B AF 89 44 7A 72 42 34 DC 7C ED 74"},
+		{NewKT128(ptn(41 * 41 * 41)), ff(7), "75 D2 F8 6A 2E 64 45 66 72 6B 4F BC FC 56 57 B9 DB CF 07 0C 7B 0D CA 06 45 0A B2 91 D7 44 3B CF"},
+		{NewKT128(nil), ptn(8191), "1B 57 76 36 F7 23 64 3E 99 0C C7 D6 A6 59 83 74 36 FD 6A 10 36 26 60 0E B8 30 1C D1 DB E5 53 D6"},
+		{NewKT128(nil), ptn(8192), "48 F2 56 F6 77 2F 9E DF B6 A8 B6 61 EC 92 DC 93 B9 5E BD 05 A0 8A 17 B3 9A E3 49 08 70 C9 26 C3"},
+		{NewKT128(ptn(8189)), ptn(8192), "3E D1 2F 70 FB 05 DD B5 86 89 51 0A B3 E4 D2 3C 6C 60 33 84 9A A0 1E 1D 8C 22 0A 29 7F ED CD 0B"},
+		{NewKT128(ptn(8190)), ptn(8192), "6A 7C 1B 6A 5C D0 D8 C9 CA 94 3A 4A 21 6C C6 46 04 55 9A 2E A4 5F 78 57 0A 15 25 3D 67 BA 00 AE"},
+	} {
+		want := unhex(t, v.want)
+		// Writing byte by byte and all at once must give the same output.
+		y := v.x.Clone()
+		_, _ = v.x.Write(v.m)
+		for i := range v.m {
+			_, _ = y.Write(v.m[i : i+1])
+		}
+		for _, x := range []XOF{v.x, y} {
+			got := make([]byte, len(want))
+			_, _ = x.Read(got)
+			if !bytes.Equal(got, want) {
+				t.Errorf("len(M): %v\ngot:  %X\nwant: %X", len(v.m), got, want)
+			}
+		}
+	}
+}
+
+// KT256 is checked against its definition on top of TurboSHAKE256, in both
+// the single-node and the tree modes.
+func TestKT256(t *testing.T) {
+	C := []byte("customization")
+	for _, n := range []int{0, 100, 8192 - 14, 8192 - 13, 3*8192 + 5} {
+		S := append(append(ptn(n), C...), lengthEncode(uint64(len(C)))...)
+		var want [100]byte
+		if len(S) <= k12ChunkSize {
+			x := NewTurboSHAKE256(0x07)
+			_, _ = x.Write(S)
+			_, _ = x.Read(want[:])
+		} else {
+			final := NewTurboSHAKE256(0x06)
+			_, _ = final.Write(S[:k12ChunkSize])
+			_, _ = final.Write([]byte{0x03, 0, 0, 0, 0, 0, 0, 0})
+			leaves := uint64(0)
+			for i := k12ChunkSize; i < len(S); i += k12ChunkSize {
+				end := i + k12ChunkSize
+				if end > len(S) {
+					end = len(S)
+				}
+				leaf := NewTurboSHAKE256(0x0B)
+				_, _ = leaf.Write(S[i:end])
+				var cv [64]byte
+				_, _ = leaf.Read(cv[:])
+				_, _ = final.Write(cv[:])
+				leaves++
+			}
+			_, _ = final.Write(lengthEncode(leaves))
+			_, _ = final.Write([]byte{0xFF, 0xFF})
+			_, _ = final.Read(want[:])
+		}
+		x := NewKT256(C)
+		_, _ = x.Write(ptn(n))
+		var got [100]byte
+		_, _ = x.Read(got[:])
+		if got != want {
+			t.Fatalf("len(M): %v\ngot:  %x\nwant: %x", n, got, want)
+		}
+	}
+}
+
+func TestLengthEncode(t *testing.T) {
+	for _, v := range []struct {
+		x    uint64
+		want []byte
+	}{
+		{0, []byte{0x00}},
+		{12, []byte{0x0C, 0x01}},
+		{65538, []byte{0x01, 0x00, 0x02, 0x03}},
+	} {
+		if got := lengthEncode(v.x); !bytes.Equal(got, v.want) {
+			t.Fatalf("x: %v\ngot:  %x\nwant: %x", v.x, got, v.want)
+		}
+	}
+}
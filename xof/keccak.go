@@ -0,0 +1,131 @@
+package xof
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// rc are the round constants of Keccak-f[1600].
+var rc = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// rho are the rotation offsets of the lane x+5y.
+var rho = [25]int{
+	0, 1, 62, 28, 27,
+	36, 44, 6, 55, 20,
+	3, 10, 43, 25, 39,
+	41, 45, 15, 21, 8,
+	18, 2, 61, 56, 14,
+}
+
+// keccakP1600 applies the last rounds of Keccak-f[1600] to a, i.e., it is
+// Keccak-p[1600, rounds].
+func keccakP1600(a *[25]uint64, rounds int) {
+	var c [5]uint64
+	var b [25]uint64
+	for r := 24 - rounds; r < 24; r++ {
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[y+x] ^= d
+			}
+		}
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				b[y+5*((2*x+3*y)%5)] = bits.RotateLeft64(a[x+5*y], rho[x+5*y])
+			}
+		}
+		for y := 0; y < 25; y += 5 {
+			for x := 0; x < 5; x++ {
+				a[y+x] = b[y+x] ^ (^b[y+(x+1)%5] & b[y+(x+2)%5])
+			}
+		}
+		a[0] ^= rc[r]
+	}
+}
+
+// sponge is a Keccak sponge whose padding starts with the domain separation
+// byte ds, as used by SHAKE and TurboSHAKE.
+type sponge struct {
+	a         [25]uint64
+	buf       [200]byte
+	n         int // n is the number of bytes in buf.
+	rate      int
+	rounds    int
+	ds        byte
+	squeezing bool
+}
+
+func (s *sponge) permute() {
+	for i := 0; i < s.rate/8; i++ {
+		s.a[i] ^= binary.LittleEndian.Uint64(s.buf[8*i:])
+	}
+	keccakP1600(&s.a, s.rounds)
+}
+
+func (s *sponge) Write(p []byte) (int, error) {
+	if s.squeezing {
+		panic("xof: Write after Read")
+	}
+	n := len(p)
+	for len(p) > 0 {
+		k := copy(s.buf[s.n:s.rate], p)
+		s.n += k
+		p = p[k:]
+		if s.n == s.rate {
+			s.permute()
+			s.n = 0
+		}
+	}
+	return n, nil
+}
+
+func (s *sponge) Read(p []byte) (int, error) {
+	if !s.squeezing {
+		for i := s.n; i < s.rate; i++ {
+			s.buf[i] = 0
+		}
+		s.buf[s.n] ^= s.ds
+		s.buf[s.rate-1] ^= 0x80
+		s.permute()
+		s.squeezing = true
+		s.n = 0
+		s.squeeze()
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if s.n == s.rate {
+			keccakP1600(&s.a, s.rounds)
+			s.squeeze()
+			s.n = 0
+		}
+		k := copy(p, s.buf[s.n:s.rate])
+		s.n += k
+		p = p[k:]
+	}
+	return n, nil
+}
+
+// squeeze copies the outer part of the state into buf.
+func (s *sponge) squeeze() {
+	for i := 0; i < s.rate/8; i++ {
+		binary.LittleEndian.PutUint64(s.buf[8*i:], s.a[i])
+	}
+}
+
+func (s *sponge) Clone() XOF { c := *s; return &c }
+
+func (s *sponge) Reset() {
+	s.a = [25]uint64{}
+	s.n = 0
+	s.squeezing = false
+}
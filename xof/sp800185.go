@@ -0,0 +1,213 @@
+package xof
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// This file implements the XOFs of NIST SP 800-185: cSHAKE, TupleHashXOF and
+// ParallelHashXOF.
+
+// NewCShake128 returns cSHAKE128 with function name N and customization
+// string S. If both are empty, it is equivalent to SHAKE128, so cSHAKE has no
+// XofID; to use it as an expander, call Register with a closure that sets N
+// and S.
+func NewCShake128(N, S []byte) XOF { return shakeBody{sha3.NewCShake128(N, S)} }
+
+// NewCShake256 returns cSHAKE256 with function name N and customization
+// string S. If both are empty, it is equivalent to SHAKE256; see NewCShake128.
+func NewCShake256(N, S []byte) XOF { return shakeBody{sha3.NewCShake256(N, S)} }
+
+// leftEncode encodes x as in Section 2.3.1 of SP 800-185.
+func leftEncode(x uint64) []byte {
+	var b [9]byte
+	binary.BigEndian.PutUint64(b[1:], x)
+	i := 1
+	for i < 8 && b[i] == 0 {
+		i++
+	}
+	b[i-1] = byte(9 - i)
+	return append([]byte{}, b[i-1:]...)
+}
+
+// rightEncode encodes x as in Section 2.3.1 of SP 800-185.
+func rightEncode(x uint64) []byte {
+	var b [9]byte
+	binary.BigEndian.PutUint64(b[:8], x)
+	i := 0
+	for i < 7 && b[i] == 0 {
+		i++
+	}
+	b[8] = byte(8 - i)
+	return append([]byte{}, b[i:]...)
+}
+
+// TupleHash is TupleHashXOF128 or TupleHashXOF256. The input is a tuple of
+// byte strings: Write appends data to the current element of the tuple, and
+// Next ends it, so writing a message in pieces does not change the tuple.
+// Since the length of an element is encoded before its data, the current
+// element is buffered until Next or Read is called.
+type TupleHash struct {
+	c    sha3.ShakeHash
+	elem []byte
+	open bool
+	done bool
+}
+
+// NewTupleHash128 returns TupleHashXOF128 with customization string S.
+func NewTupleHash128(S []byte) *TupleHash {
+	return &TupleHash{c: sha3.NewCShake128([]byte("TupleHash"), S)}
+}
+
+// NewTupleHash256 returns TupleHashXOF256 with customization string S.
+func NewTupleHash256(S []byte) *TupleHash {
+	return &TupleHash{c: sha3.NewCShake256([]byte("TupleHash"), S)}
+}
+
+// Write appends p to the current element of the tuple. It panics if called
+// after Read.
+func (t *TupleHash) Write(p []byte) (int, error) {
+	if t.done {
+		panic("xof: Write after Read")
+	}
+	t.elem = append(t.elem, p...)
+	t.open = true
+	return len(p), nil
+}
+
+// Next ends the current element of the tuple, even if it is empty.
+func (t *TupleHash) Next() {
+	if t.done {
+		panic("xof: Write after Read")
+	}
+	_, _ = t.c.Write(leftEncode(uint64(len(t.elem)) * 8))
+	_, _ = t.c.Write(t.elem)
+	t.elem = t.elem[:0]
+	t.open = false
+}
+
+// Read ends the current element, if any, and reads output of the XOF.
+func (t *TupleHash) Read(p []byte) (int, error) {
+	if !t.done {
+		if t.open {
+			t.Next()
+		}
+		_, _ = t.c.Write(rightEncode(0))
+		t.done = true
+	}
+	return t.c.Read(p)
+}
+
+// Clone returns a copy of the XOF in its current state.
+func (t *TupleHash) Clone() XOF {
+	return &TupleHash{t.c.Clone(), append([]byte{}, t.elem...), t.open, t.done}
+}
+
+// Reset resets the XOF to its initial state, keeping its customization string.
+func (t *TupleHash) Reset() {
+	t.c.Reset()
+	t.elem = t.elem[:0]
+	t.open, t.done = false, false
+}
+
+// ParallelHash is ParallelHashXOF128 or ParallelHashXOF256. The input is split
+// in blocks of B bytes that are hashed independently.
+type ParallelHash struct {
+	c     sha3.ShakeHash
+	inner func() sha3.ShakeHash
+	size  int // size is the length of the hash of each block.
+	b     int
+	buf   []byte
+	n     uint64
+	done  bool
+}
+
+// NewParallelHash128 returns ParallelHashXOF128 with block size B in bytes and
+// customization string S.
+func NewParallelHash128(B int, S []byte) *ParallelHash {
+	return newParallelHash(sha3.NewCShake128([]byte("ParallelHash"), S), sha3.NewShake128, 32, B)
+}
+
+// NewParallelHash256 returns ParallelHashXOF256 with block size B in bytes and
+// customization string S.
+func NewParallelHash256(B int, S []byte) *ParallelHash {
+	return newParallelHash(sha3.NewCShake256([]byte("ParallelHash"), S), sha3.NewShake256, 64, B)
+}
+
+func newParallelHash(c sha3.ShakeHash, inner func() sha3.ShakeHash, size, B int) *ParallelHash {
+	if B <= 0 {
+		panic("xof: invalid block size")
+	}
+	p := &ParallelHash{c: c, inner: inner, size: size, b: B}
+	p.Reset()
+	return p
+}
+
+func (p *ParallelHash) block(x []byte) {
+	h := p.inner()
+	_, _ = h.Write(x)
+	out := make([]byte, p.size)
+	_, _ = h.Read(out)
+	_, _ = p.c.Write(out)
+	p.n++
+}
+
+// Write absorbs more data. It panics if called after Read.
+func (p *ParallelHash) Write(x []byte) (int, error) {
+	if p.done {
+		panic("xof: Write after Read")
+	}
+	n := len(x)
+	if len(p.buf) > 0 {
+		k := p.b - len(p.buf)
+		if k > len(x) {
+			k = len(x)
+		}
+		p.buf = append(p.buf, x[:k]...)
+		x = x[k:]
+		if len(p.buf) < p.b {
+			return n, nil
+		}
+		p.block(p.buf)
+		p.buf = p.buf[:0]
+	}
+	for len(x) >= p.b {
+		p.block(x[:p.b])
+		x = x[p.b:]
+	}
+	p.buf = append(p.buf, x...)
+	return n, nil
+}
+
+// Read hashes the last block, if any, and reads output of the XOF.
+func (p *ParallelHash) Read(x []byte) (int, error) {
+	if !p.done {
+		if len(p.buf) > 0 {
+			p.block(p.buf)
+			p.buf = p.buf[:0]
+		}
+		_, _ = p.c.Write(rightEncode(p.n))
+		_, _ = p.c.Write(rightEncode(0))
+		p.done = true
+	}
+	return p.c.Read(x)
+}
+
+// Clone returns a copy of the XOF in its current state.
+func (p *ParallelHash) Clone() XOF {
+	q := *p
+	q.c = p.c.Clone()
+	q.buf = append([]byte{}, p.buf...)
+	return &q
+}
+
+// Reset resets the XOF to its initial state, keeping its block size and
+// customization string.
+func (p *ParallelHash) Reset() {
+	p.c.Reset()
+	_, _ = p.c.Write(leftEncode(uint64(p.b)))
+	p.buf = p.buf[:0]
+	p.n = 0
+	p.done = false
+}
@@ -0,0 +1,110 @@
+package xof_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/armfazh/h2c-go-ref/xof"
+)
+
+// seq returns the bytes 0x00, 0x01, ..., n-1.
+func seq(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return b
+}
+
+func fromHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(strings.Replace(s, " ", "", -1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func read(x xof.XOF, n int) []byte {
+	out := make([]byte, n)
+	_, _ = io.ReadFull(x, out)
+	return out
+}
+
+// Test vectors from the NIST examples of SP 800-185.
+func TestCShake(t *testing.T) {
+	S := []byte("Email Signature")
+	for _, v := range []struct {
+		x    xof.XOF
+		in   []byte
+		want string
+	}{
+		{xof.NewCShake128(nil, S), seq(4), "C1C36925B6409A04F1B504FCBCA9D82B4017277CB5ED2B2065FC1D3814D5AAF5"},
+		{xof.NewCShake128(nil, S), seq(200), "C5221D50E4F822D96A2E8881A961420F294B7B24FE3D2094BAED2C6524CC166B"},
+		{xof.NewCShake256(nil, S), seq(4), "D008828E2B80AC9D2218FFEE1D070C48B8E4C87BFF32C9699D5B6896EEE0EDD164020E2BE0560858D9C00C037E34A96937C561A74C412BB4C746469527281C8C"},
+		{xof.NewCShake256(nil, S), seq(200), "07DC27B11E51FBAC75BC7B3C1D983E8B4B85FB1DEFAF218912AC86430273091727F42B17ED1DF63E8EC118F04B23633C1DFB1574C8FB55CB45DA8E25AFB092BB"},
+	} {
+		_, _ = v.x.Write(v.in)
+		want := fromHex(t, v.want)
+		if got := read(v.x, len(want)); !bytes.Equal(got, want) {
+			t.Fatalf("got:  %X\nwant: %X", got, want)
+		}
+	}
+}
+
+func TestTupleHash(t *testing.T) {
+	tuple2 := [][]byte{seq(3), {0x10, 0x11, 0x12, 0x13, 0x14, 0x15}}
+	tuple3 := append(tuple2, []byte{0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28})
+	S := []byte("My Tuple App")
+	for _, v := range []struct {
+		x     *xof.TupleHash
+		tuple [][]byte
+		want  string
+	}{
+		{xof.NewTupleHash128(nil), tuple2, "2F103CD7C32320353495C68DE1A8129245C6325F6F2A3D608D92179C96E68488"},
+		{xof.NewTupleHash128(S), tuple2, "3FC8AD69453128292859A18B6C67D7AD85F01B32815E22CE839C49EC374E9B9A"},
+		{xof.NewTupleHash128(S), tuple3, "900FE16CAD098D28E74D632ED852F99DAAB7F7DF4D99E775657885B4BF76D6F8"},
+		{xof.NewTupleHash256(nil), tuple2, "03DED4610ED6450A1E3F8BC44951D14FBC384AB0EFE57B000DF6B6DF5AAE7CD568E77377DAF13F37EC75CF5FC598B6841D51DD207C991CD45D210BA60AC52EB9"},
+	} {
+		for _, e := range v.tuple {
+			_, _ = v.x.Write(e)
+			v.x.Next()
+		}
+		want := fromHex(t, v.want)
+		if got := read(v.x.Clone(), len(want)); !bytes.Equal(got, want) {
+			t.Fatalf("got:  %X\nwant: %X", got, want)
+		}
+		if got := read(v.x, len(want)); !bytes.Equal(got, want) {
+			t.Fatalf("got:  %X\nwant: %X", got, want)
+		}
+	}
+}
+
+func TestParallelHash(t *testing.T) {
+	in := fromHex(t, "000102030405060710111213141516172021222324252627")
+	for _, v := range []struct {
+		x    *xof.ParallelHash
+		want string
+	}{
+		{xof.NewParallelHash128(8, nil), "FE47D661E49FFE5B7D999922C062356750CAF552985B8E8CE6667F2727C3C8D3"},
+		{xof.NewParallelHash128(8, []byte("Parallel Data")), "EA2A793140820F7A128B8EB70A9439F93257C6E6E79B4A540D291D6DAE7098D7"},
+		{xof.NewParallelHash256(8, nil), "C10A052722614684144D28474850B410757E3CBA87651BA167A5CBDDFF7F466675FBF84BCAE7378AC444BE681D729499AFCA667FB879348BFDDA427863C82F1C"},
+	} {
+		// Writing in pieces that do not match the block size must not change
+		// the output.
+		_, _ = v.x.Write(in[:5])
+		_, _ = v.x.Write(in[5:19])
+		_, _ = v.x.Write(in[19:])
+		want := fromHex(t, v.want)
+		if got := read(v.x, len(want)); !bytes.Equal(got, want) {
+			t.Fatalf("got:  %X\nwant: %X", got, want)
+		}
+		v.x.Reset()
+		_, _ = v.x.Write(in)
+		if got := read(v.x, len(want)); !bytes.Equal(got, want) {
+			t.Fatalf("got:  %X\nwant: %X", got, want)
+		}
+	}
+}
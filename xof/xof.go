@@ -1,8 +1,11 @@
 package xof
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"strconv"
+	"sync"
 
 	"golang.org/x/crypto/blake2b"
 	"golang.org/x/crypto/blake2s"
@@ -28,45 +31,146 @@ type XOF interface {
 
 type XOFFunc func() XOF
 
+// XofID identifies an XOF registered in this package.
 type XofID uint
 
-func (x XofID) Available() bool  { return x < maxXofID && xofRegistry[x] != nil }
+// Errors returned by the registry of XOFs.
+var (
+	ErrRegistered = errors.New("xof: name already registered")
+	ErrUnknown    = errors.New("xof: unknown name")
+)
+
+type xofInfo struct {
+	name     string
+	size     int
+	security uint
+	new      func() XOF
+}
+
+var (
+	registryMu  sync.RWMutex
+	xofRegistry []xofInfo
+)
+
+func (x XofID) info() (xofInfo, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if x < XofID(len(xofRegistry)) && xofRegistry[x].new != nil {
+		return xofRegistry[x], true
+	}
+	return xofInfo{}, false
+}
+
+func (x XofID) Available() bool  { _, ok := x.info(); return ok }
 func (x XofID) XofIDFunc() XofID { return x }
 func (x XofID) New() XOF {
-	if x < maxXofID {
-		f := xofRegistry[x]
-		if f != nil {
-			return f()
-		}
+	if info, ok := x.info(); ok {
+		return info.new()
 	}
 	panic("crypto: requested XOF function #" + strconv.Itoa(int(x)) + " is unavailable")
 }
 
+// String returns the name of the XOF, e.g. "SHAKE128".
+func (x XofID) String() string {
+	if info, ok := x.info(); ok && info.name != "" {
+		return info.name
+	}
+	return "XofID(" + strconv.Itoa(int(x)) + ")"
+}
+
+// Size returns the number of bytes of output needed to reach the security
+// level of the XOF, i.e. twice the security level.
+func (x XofID) Size() int { info, _ := x.info(); return info.size }
+
+// SecurityLevel returns the security level of the XOF in bits.
+func (x XofID) SecurityLevel() uint { info, _ := x.info(); return info.security }
+
+// ParseXOF returns the XofID of a registered XOF given its name.
+func ParseXOF(name string) (XofID, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for i := range xofRegistry {
+		if xofRegistry[i].new != nil && xofRegistry[i].name == name {
+			return XofID(i), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %v", ErrUnknown, name)
+}
+
+// RegisterXOF sets the function that returns new instances of the XOF x.
+// Registering an XofID not defined in this package allocates it without a
+// name; use Register to add new algorithms.
 func RegisterXOF(x XofID, f func() XOF) {
-	if x >= maxXofID {
-		panic("crypto: RegisterXOF of unknown XOF function")
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for XofID(len(xofRegistry)) <= x {
+		xofRegistry = append(xofRegistry, xofInfo{})
+	}
+	xofRegistry[x].new = f
+}
+
+// Register allocates a new XofID for an XOF with a unique name and the given
+// security level in bits.
+func Register(name string, security uint, f func() XOF) (XofID, error) {
+	if name == "" || f == nil {
+		return 0, errors.New("xof: invalid registration")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for i := range xofRegistry {
+		if xofRegistry[i].name == name {
+			return 0, fmt.Errorf("%w: %v", ErrRegistered, name)
+		}
 	}
-	xofRegistry[x] = f
+	xofRegistry = append(xofRegistry, xofInfo{name, int(2*security+7) / 8, security, f})
+	return XofID(len(xofRegistry) - 1), nil
 }
 
-var xofRegistry = make([]func() XOF, maxXofID)
+func mustRegister(x XofID, name string, security uint, f func() XOF) {
+	if id, err := Register(name, security, f); err != nil || id != x {
+		panic("xof: cannot register " + name)
+	}
+}
 
 func init() {
-	RegisterXOF(SHAKE128, newShake128)
-	RegisterXOF(SHAKE256, newShake256)
-	RegisterXOF(BLAKE2XB, newBlake2xb)
-	RegisterXOF(BLAKE2XS, newBlake2xs)
+	mustRegister(SHAKE128, "SHAKE128", 128, newShake128)
+	mustRegister(SHAKE256, "SHAKE256", 256, newShake256)
+	mustRegister(BLAKE2XB, "BLAKE2XB", 256, newBlake2xb)
+	mustRegister(BLAKE2XS, "BLAKE2XS", 128, newBlake2xs)
+	mustRegister(TUPLEHASH128, "TUPLEHASH128", 128, func() XOF { return NewTupleHash128(nil) })
+	mustRegister(TUPLEHASH256, "TUPLEHASH256", 256, func() XOF { return NewTupleHash256(nil) })
+	mustRegister(PARALLELHASH128, "PARALLELHASH128", 128, func() XOF { return NewParallelHash128(ParallelHashBlockSize, nil) })
+	mustRegister(PARALLELHASH256, "PARALLELHASH256", 256, func() XOF { return NewParallelHash256(ParallelHashBlockSize, nil) })
+	mustRegister(TURBOSHAKE128, "TURBOSHAKE128", 128, func() XOF { return NewTurboSHAKE128(0x1F) })
+	mustRegister(TURBOSHAKE256, "TURBOSHAKE256", 256, func() XOF { return NewTurboSHAKE256(0x1F) })
+	mustRegister(KT128, "KT128", 128, func() XOF { return NewKT128(nil) })
+	mustRegister(KT256, "KT256", 256, func() XOF { return NewKT256(nil) })
+	mustRegister(BLAKE3XOF, "BLAKE3", 128, func() XOF { return NewBLAKE3() })
+	mustRegister(ASCONXOF128, "ASCONXOF128", 128, NewAsconXOF128)
+	mustRegister(ASCONCXOF128, "ASCONCXOF128", 128, func() XOF { return NewAsconCXOF128(nil) })
 }
 
-const maxXofID = 4
-
 const (
 	SHAKE128 XofID = iota
 	SHAKE256
 	BLAKE2XB
 	BLAKE2XS
+	TUPLEHASH128    // TUPLEHASH128 is TupleHashXOF128 with empty customization string.
+	TUPLEHASH256    // TUPLEHASH256 is TupleHashXOF256 with empty customization string.
+	PARALLELHASH128 // PARALLELHASH128 is ParallelHashXOF128 with blocks of ParallelHashBlockSize bytes.
+	PARALLELHASH256 // PARALLELHASH256 is ParallelHashXOF256 with blocks of ParallelHashBlockSize bytes.
+	TURBOSHAKE128   // TURBOSHAKE128 is TurboSHAKE128 with domain separation byte 0x1F.
+	TURBOSHAKE256   // TURBOSHAKE256 is TurboSHAKE256 with domain separation byte 0x1F.
+	KT128           // KT128 is KangarooTwelve with empty customization string.
+	KT256           // KT256 is KangarooTwelve with empty customization string.
+	BLAKE3XOF       // BLAKE3XOF is BLAKE3 in hash mode.
+	ASCONXOF128     // ASCONXOF128 is Ascon-XOF128.
+	ASCONCXOF128    // ASCONCXOF128 is Ascon-CXOF128 with empty customization string.
 )
 
+// ParallelHashBlockSize is the block size used by the registered ParallelHash XOFs.
+const ParallelHashBlockSize = 8192
+
 type shakeBody struct{ sha3.ShakeHash }
 
 func (s shakeBody) Clone() XOF { return shakeBody{s.ShakeHash.Clone()} }
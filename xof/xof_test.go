@@ -0,0 +1,63 @@
+package xof_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/armfazh/h2c-go-ref/xof"
+	"golang.org/x/crypto/sha3"
+)
+
+type cshake struct{ sha3.ShakeHash }
+
+func (c cshake) Clone() xof.XOF { return cshake{c.ShakeHash.Clone()} }
+
+func TestRegistry(t *testing.T) {
+	for _, v := range []struct {
+		id       xof.XofID
+		name     string
+		size     int
+		security uint
+	}{
+		{xof.SHAKE128, "SHAKE128", 32, 128},
+		{xof.SHAKE256, "SHAKE256", 64, 256},
+		{xof.BLAKE2XB, "BLAKE2XB", 64, 256},
+		{xof.BLAKE2XS, "BLAKE2XS", 32, 128},
+	} {
+		if got := v.id.String(); got != v.name {
+			t.Fatalf("got: %v want: %v", got, v.name)
+		}
+		if got, err := xof.ParseXOF(v.name); err != nil || got != v.id {
+			t.Fatalf("got: %v, %v want: %v", got, err, v.id)
+		}
+		if v.id.Size() != v.size || v.id.SecurityLevel() != v.security {
+			t.Fatalf("%v got: %v %v want: %v %v", v.name, v.id.Size(), v.id.SecurityLevel(), v.size, v.security)
+		}
+	}
+
+	f := func() xof.XOF { return cshake{sha3.NewCShake128(nil, []byte("registry"))} }
+	id, err := xof.Register("CSHAKE128-REGISTRY", 128, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := xof.ParseXOF("CSHAKE128-REGISTRY"); err != nil || got != id || !id.Available() {
+		t.Fatalf("got: %v, %v want: %v", got, err, id)
+	}
+	got, want := make([]byte, 32), make([]byte, 32)
+	_, _ = io.ReadFull(id.New(), got)
+	_, _ = io.ReadFull(f(), want)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got:  %x\nwant: %x", got, want)
+	}
+	if _, err := xof.Register("SHAKE128", 128, f); !errors.Is(err, xof.ErrRegistered) {
+		t.Fatalf("got:  %v\nwant: %v", err, xof.ErrRegistered)
+	}
+	if _, err := xof.ParseXOF("SHAKE512"); !errors.Is(err, xof.ErrUnknown) {
+		t.Fatalf("got:  %v\nwant: %v", err, xof.ErrUnknown)
+	}
+	if s := xof.XofID(1000).String(); s != "XofID(1000)" {
+		t.Fatalf("got: %v", s)
+	}
+}
@@ -0,0 +1,35 @@
+package h2c
+
+import (
+	"hash"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// XMDBLAKE2bZcash is the id of the expander registered with the HASH_ID
+// "XMD:BLAKE2b". It is the expand_message_xmd variant that Zcash uses to hash
+// to the Pallas and Vesta curves. That variant uses BLAKE2b-512, but Z_pad has
+// 64 bytes, the output size of BLAKE2b-512, rather than the 128-byte block
+// size. The expander of RFC 9380 is available as "XMD:BLAKE2b-512".
+const XMDBLAKE2bZcash uint = 0x7a63
+
+func newExpanderZcash(dst []byte, k uint) (Expander, error) {
+	return NewExpanderXMD(newBLAKE2bZcash, dst, k)
+}
+
+// blake2bZcash is BLAKE2b-512 reporting a block size of 64 bytes, so
+// NewExpanderXMD uses the Z_pad of Zcash.
+type blake2bZcash struct{ hash.Hash }
+
+func newBLAKE2bZcash() hash.Hash {
+	h, _ := blake2b.New512(nil)
+	return blake2bZcash{h}
+}
+
+func (blake2bZcash) BlockSize() int { return blake2b.Size }
+func (h blake2bZcash) MarshalBinary() ([]byte, error) {
+	return h.Hash.(binaryState).MarshalBinary()
+}
+func (h blake2bZcash) UnmarshalBinary(b []byte) error {
+	return h.Hash.(binaryState).UnmarshalBinary(b)
+}